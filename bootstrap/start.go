@@ -2,7 +2,12 @@ package bootstrap
 
 import (
 	"context"
+	"goumang-worker/services/artifact"
+	"goumang-worker/services/cron"
+	"goumang-worker/services/executor/plugin"
 	"goumang-worker/services/goumang"
+	"goumang-worker/services/pull"
+	"goumang-worker/services/registry"
 	"path"
 
 	"github.com/bpcoder16/Chestnut/v2/appconfig"
@@ -18,12 +23,42 @@ func Start(ctx context.Context, config *appconfig.AppConfig) error {
 
 	bootstrap.Start(ctx, config, g.Go)
 
+	// 定期清理过期的任务产物
+	g.Go(func() error {
+		return artifact.RunCleaner(ctx)
+	})
+
+	// 启动并监管执行器插件
+	g.Go(func() error {
+		return plugin.Run(ctx)
+	})
+
+	server := goumang.NewServer()
+
+	// 按本地任务定义文件定时执行任务，与运行模式无关
+	g.Go(func() error {
+		return cron.Run(ctx, server)
+	})
+
+	// 拉取模式：主动连接调度端接收任务，不监听入站端口
+	if pull.IsEnabled() {
+		g.Go(func() error {
+			return pull.Run(ctx, server)
+		})
+		return g.Wait()
+	}
+
 	g.Go(func() error {
 		return grpcserver.NewManager(
 			path.Join(env.ConfigDirPath(), "grpc.yaml"),
-			goumang.NewServer(),
+			server,
 		).Run(ctx)
 	})
 
+	// 向调度端注册并发送心跳，退出时注销
+	g.Go(func() error {
+		return registry.Run(ctx, server)
+	})
+
 	return g.Wait()
 }
@@ -0,0 +1,250 @@
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"goumang-worker/services/internal/pathguard"
+	"goumang-worker/services/pb"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+)
+
+var (
+	// ErrInvalidPath 路径非法（绝对路径或越出目录）
+	ErrInvalidPath = errors.New("invalid artifact path")
+	// ErrNotFound 产物不存在
+	ErrNotFound = errors.New("artifact not found")
+)
+
+// cleanInterval 过期产物清理间隔
+const cleanInterval = 10 * time.Minute
+
+// ValidatePatterns 校验产物匹配模式，只允许工作目录内的相对路径
+// 产物按 runTaskId 暂存，声明产物时 runTaskId 不能为 0
+func ValidatePatterns(runTaskID uint64, patterns []string) error {
+	if len(patterns) > 0 && runTaskID == 0 {
+		return errors.New("run_task_id is required to collect artifacts")
+	}
+	for _, pattern := range patterns {
+		if _, ok := pathguard.CleanRelPath(pattern, false); !ok {
+			return fmt.Errorf("%w: %q", ErrInvalidPath, pattern)
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("bad artifact pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Collect 在任务结束后按模式收集工作目录中的产物，复制到暂存目录并返回清单
+// 没有 runTaskId 或任务未使用独立工作目录时不收集，避免覆盖其他任务的产物或匹配到 worker 自身的文件
+func Collect(ctx context.Context, runTaskID uint64, workDir string, patterns []string) []*pb.Artifact {
+	if len(patterns) == 0 {
+		return nil
+	}
+	if runTaskID == 0 || workDir == "" {
+		logit.Context(ctx).WarnW("logType", "artifacts skipped", "runTaskId", runTaskID, "reason", "no run_task_id or work dir")
+		return nil
+	}
+
+	cfg := GetArtifactConfig()
+	workDir, err := filepath.Abs(workDir)
+	if err == nil {
+		workDir, err = filepath.EvalSymlinks(workDir)
+	}
+	if err != nil {
+		logit.Context(ctx).WarnW("artifact.workDir.Err", err)
+		return nil
+	}
+
+	runDir := runDirPath(cfg, runTaskID)
+	if err = os.RemoveAll(runDir); err != nil {
+		logit.Context(ctx).WarnW("artifact.RemoveAll.Err", err)
+		return nil
+	}
+
+	var (
+		artifacts  []*pb.Artifact
+		totalBytes int64
+		seen       = make(map[string]bool)
+	)
+	for _, pattern := range patterns {
+		rel, ok := pathguard.CleanRelPath(pattern, false)
+		if !ok {
+			logit.Context(ctx).WarnW("artifact.pattern.Err", ErrInvalidPath, "pattern", pattern)
+			continue
+		}
+		matches, errG := filepath.Glob(filepath.Join(workDir, rel))
+		if errG != nil {
+			logit.Context(ctx).WarnW("artifact.Glob.Err", errG, "pattern", pattern)
+			continue
+		}
+
+		for _, match := range matches {
+			if len(artifacts) >= cfg.MaxFiles {
+				logit.Context(ctx).WarnW("artifact.maxFiles.exceeded", cfg.MaxFiles)
+				return artifacts
+			}
+
+			// 解析符号链接，防止越出工作目录
+			realPath, errE := filepath.EvalSymlinks(match)
+			if errE != nil || !pathguard.IsWithin(workDir, realPath) || seen[realPath] {
+				continue
+			}
+			info, errS := os.Stat(realPath)
+			if errS != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			if cfg.MaxFileBytes > 0 && info.Size() > cfg.MaxFileBytes {
+				logit.Context(ctx).WarnW("artifact.maxFileBytes.exceeded", match, "size", info.Size())
+				continue
+			}
+			if cfg.MaxTotalBytes > 0 && totalBytes+info.Size() > cfg.MaxTotalBytes {
+				logit.Context(ctx).WarnW("artifact.maxTotalBytes.exceeded", match, "size", info.Size())
+				continue
+			}
+
+			// 文件可能在检查后继续增长，复制时按剩余额度截止
+			limit := cfg.MaxFileBytes
+			if cfg.MaxTotalBytes > 0 && (limit <= 0 || cfg.MaxTotalBytes-totalBytes < limit) {
+				limit = cfg.MaxTotalBytes - totalBytes
+			}
+			relPath, _ := filepath.Rel(workDir, match)
+			artifact, errC := copyToSpool(realPath, runDir, relPath, limit)
+			if errC != nil {
+				logit.Context(ctx).WarnW("artifact.copy.Err", errC, "path", relPath)
+				continue
+			}
+			seen[realPath] = true
+			totalBytes += int64(artifact.Size)
+			artifacts = append(artifacts, artifact)
+		}
+	}
+
+	return artifacts
+}
+
+// Open 打开已收集的产物
+func Open(runTaskID uint64, artifactPath string) (*os.File, error) {
+	rel, ok := pathguard.CleanRelPath(artifactPath, false)
+	if !ok {
+		return nil, ErrInvalidPath
+	}
+
+	runDir := runDirPath(GetArtifactConfig(), runTaskID)
+	fullPath := filepath.Join(runDir, rel)
+	realPath, err := filepath.EvalSymlinks(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	realRunDir, err := filepath.EvalSymlinks(runDir)
+	if err != nil || !pathguard.IsWithin(realRunDir, realPath) {
+		return nil, ErrInvalidPath
+	}
+
+	info, err := os.Stat(realPath)
+	if err != nil || !info.Mode().IsRegular() {
+		return nil, ErrNotFound
+	}
+	return os.Open(realPath)
+}
+
+// RunCleaner 定期清理超过保留期的产物，直到 ctx 结束
+func RunCleaner(ctx context.Context) error {
+	cfg := GetArtifactConfig()
+	if cfg.RetentionHours <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(cleanInterval)
+	defer ticker.Stop()
+	for {
+		cleanExpired(ctx, cfg)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// cleanExpired 删除修改时间早于保留期的运行目录
+func cleanExpired(ctx context.Context, cfg ArtifactConfig) {
+	entries, err := os.ReadDir(cfg.SpoolDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logit.Context(ctx).WarnW("artifact.ReadDir.Err", err)
+		}
+		return
+	}
+
+	expireBefore := time.Now().Add(-time.Duration(cfg.RetentionHours) * time.Hour)
+	for _, entry := range entries {
+		info, errI := entry.Info()
+		if errI != nil || !entry.IsDir() || info.ModTime().After(expireBefore) {
+			continue
+		}
+		if errR := os.RemoveAll(filepath.Join(cfg.SpoolDir, entry.Name())); errR != nil {
+			logit.Context(ctx).WarnW("artifact.clean.Err", errR)
+		}
+	}
+}
+
+// copyToSpool 复制单个文件到暂存目录并计算 sha256，超过 limit 字节时失败（limit 不大于 0 表示不限制）
+func copyToSpool(srcPath, runDir, relPath string, limit int64) (*pb.Artifact, error) {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	dstPath := filepath.Join(runDir, relPath)
+	if err = os.MkdirAll(filepath.Dir(dstPath), 0o750); err != nil {
+		return nil, err
+	}
+	dst, err := os.OpenFile(dstPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader = src
+	if limit > 0 {
+		reader = io.LimitReader(src, limit+1)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(dst, hash), reader)
+	if errC := dst.Close(); err == nil {
+		err = errC
+	}
+	if err == nil && limit > 0 && size > limit {
+		err = fmt.Errorf("file grew beyond %d bytes while copying", limit)
+	}
+	if err != nil {
+		_ = os.Remove(dstPath)
+		return nil, err
+	}
+
+	return &pb.Artifact{
+		Path:   filepath.ToSlash(relPath),
+		Size:   uint64(size),
+		Sha256: hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// runDirPath 返回运行 ID 对应的暂存目录
+func runDirPath(cfg ArtifactConfig, runTaskID uint64) string {
+	return filepath.Join(cfg.SpoolDir, strconv.FormatUint(runTaskID, 10))
+}
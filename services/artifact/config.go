@@ -0,0 +1,61 @@
+package artifact
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// ArtifactConfig 产物配置
+type ArtifactConfig struct {
+	// 产物暂存目录
+	SpoolDir string `yaml:"spoolDir"`
+	// 单个任务最多收集的文件数
+	MaxFiles int `yaml:"maxFiles"`
+	// 单个文件最大字节数，超出的文件会被跳过，0 表示不限制
+	MaxFileBytes int64 `yaml:"maxFileBytes"`
+	// 单个任务产物总字节数上限，0 表示不限制
+	MaxTotalBytes int64 `yaml:"maxTotalBytes"`
+	// 产物保留小时数，0 表示不自动清理
+	RetentionHours int `yaml:"retentionHours"`
+	// FetchArtifact 每个分片的字节数
+	ChunkSize int `yaml:"chunkSize"`
+}
+
+// Config 产物配置文件结构
+type Config struct {
+	Artifact ArtifactConfig `yaml:"artifact"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "artifact.yaml"), &globalConfig); err != nil {
+			panic("loadConfig artifact.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if globalConfig.Artifact.SpoolDir == "" {
+			globalConfig.Artifact.SpoolDir = path.Join(env.RootPath(), "data", "artifacts")
+		}
+		if globalConfig.Artifact.MaxFiles <= 0 {
+			globalConfig.Artifact.MaxFiles = 100
+		}
+		if globalConfig.Artifact.ChunkSize <= 0 {
+			globalConfig.Artifact.ChunkSize = 64 * 1024
+		}
+	})
+}
+
+// GetArtifactConfig 获取产物配置
+func GetArtifactConfig() ArtifactConfig {
+	lazyLoadConfig()
+	return globalConfig.Artifact
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// CronConfig 本地定时任务配置
+type CronConfig struct {
+	// 是否启用本地定时任务
+	Enabled bool `yaml:"enabled"`
+	// 任务定义文件（为空时使用配置目录下的 schedule.yaml）
+	ScheduleFile string `yaml:"scheduleFile"`
+	// 检查任务定义文件变更的间隔秒数
+	ReloadIntervalSec int `yaml:"reloadIntervalSec"`
+	// 任务输出目录（为空时使用 <rootPath>/data/cron/output）
+	OutputDir string `yaml:"outputDir"`
+	// 输出文件保留小时数（0 表示不清理）
+	OutputRetentionHours int `yaml:"outputRetentionHours"`
+	// 执行历史文件（为空时使用 <rootPath>/data/cron/history.jsonl）
+	HistoryFile string `yaml:"historyFile"`
+	// 执行历史文件最大字节数，超出后轮转为 .1 文件（0 表示不轮转）
+	HistoryMaxBytes int64 `yaml:"historyMaxBytes"`
+}
+
+// Config 本地定时任务配置结构
+type Config struct {
+	Cron CronConfig `yaml:"cron"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "cron.yaml"), &globalConfig); err != nil {
+			panic("loadConfig cron.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if globalConfig.Cron.ScheduleFile == "" {
+			globalConfig.Cron.ScheduleFile = path.Join(env.ConfigDirPath(), "schedule.yaml")
+		}
+		if globalConfig.Cron.ReloadIntervalSec <= 0 {
+			globalConfig.Cron.ReloadIntervalSec = 10
+		}
+		if globalConfig.Cron.OutputDir == "" {
+			globalConfig.Cron.OutputDir = path.Join(env.RootPath(), "data", "cron", "output")
+		}
+		if globalConfig.Cron.HistoryFile == "" {
+			globalConfig.Cron.HistoryFile = path.Join(env.RootPath(), "data", "cron", "history.jsonl")
+		}
+	})
+}
+
+// GetCronConfig 获取本地定时任务配置
+func GetCronConfig() CronConfig {
+	lazyLoadConfig()
+	return globalConfig.Cron
+}
@@ -0,0 +1,224 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"goumang-worker/services/cron/config"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/pb"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// tickInterval 检查任务是否到期的间隔
+	tickInterval = time.Second
+	// cleanInterval 过期输出清理间隔
+	cleanInterval = 10 * time.Minute
+)
+
+// TaskExecutor 执行任务请求，goumang.Server 即满足
+type TaskExecutor interface {
+	Execute(ctx context.Context, req *pb.TaskRequest, sink executor.Sink) error
+}
+
+// entry 调度中的任务
+type entry struct {
+	job  *parsedJob
+	next time.Time
+	// 正在执行的实例，runTaskId 到取消函数；任务定义变更后沿用
+	running map[uint64]context.CancelFunc
+}
+
+// scheduler 本地定时任务调度器
+type scheduler struct {
+	cfg  config.CronConfig
+	exec TaskExecutor
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	// 任务定义文件上次加载时的修改时间与大小
+	modTime time.Time
+	size    int64
+	// 上一次分配的 runTaskId，同一时刻触发的任务也不重复
+	lastRunTaskID uint64
+
+	wg sync.WaitGroup
+}
+
+// Run 按任务定义文件定时执行任务并定期重新加载定义，ctx 结束时取消执行中的任务并等待其结束
+func Run(ctx context.Context, exec TaskExecutor) error {
+	cfg := config.GetCronConfig()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	s := &scheduler{cfg: cfg, exec: exec, entries: make(map[string]*entry)}
+	s.reload(ctx)
+	cleanOutput(ctx, cfg)
+
+	tick := time.NewTicker(tickInterval)
+	defer tick.Stop()
+	reload := time.NewTicker(time.Duration(cfg.ReloadIntervalSec) * time.Second)
+	defer reload.Stop()
+	clean := time.NewTicker(cleanInterval)
+	defer clean.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.wg.Wait()
+			return nil
+		case now := <-tick.C:
+			s.fire(ctx, now)
+		case <-reload.C:
+			s.reload(ctx)
+		case <-clean.C:
+			cleanOutput(ctx, cfg)
+		}
+	}
+}
+
+// reload 任务定义文件的修改时间或大小变化时重新加载，解析失败时保留原有任务
+// 定义未变的任务保留下一次触发时间，被删除的任务不再触发，执行中的实例继续运行
+func (s *scheduler) reload(ctx context.Context) {
+	var modTime time.Time
+	var size int64
+	info, err := os.Stat(s.cfg.ScheduleFile)
+	switch {
+	case err == nil:
+		modTime, size = info.ModTime(), info.Size()
+	case !os.IsNotExist(err):
+		logit.Context(ctx).WarnW("cron.schedule.Stat.Err", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if modTime.Equal(s.modTime) && size == s.size {
+		return
+	}
+	s.modTime, s.size = modTime, size
+
+	jobs := make(map[string]*parsedJob)
+	if err == nil {
+		if jobs, err = loadSchedule(ctx, s.cfg.ScheduleFile); err != nil {
+			logit.Context(ctx).WarnW("cron.schedule.Load.Err", err, "file", s.cfg.ScheduleFile)
+			return
+		}
+	}
+
+	now := time.Now()
+	entries := make(map[string]*entry, len(jobs))
+	for name, job := range jobs {
+		old, exists := s.entries[name]
+		if exists && old.job.Job == job.Job {
+			entries[name] = old
+			continue
+		}
+		e := &entry{job: job, next: job.schedule.Next(now), running: make(map[uint64]context.CancelFunc)}
+		if exists {
+			e.running = old.running
+		}
+		entries[name] = e
+	}
+	s.entries = entries
+	logit.Context(ctx).InfoW("logType", "cron schedule loaded", "file", s.cfg.ScheduleFile, "jobs", len(entries))
+}
+
+// fire 启动所有到期的任务
+func (s *scheduler) fire(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.entries {
+		if e.next.IsZero() || now.Before(e.next) {
+			continue
+		}
+		e.next = e.job.schedule.Next(now)
+		s.start(ctx, e, now)
+	}
+}
+
+// start 按重叠策略启动一次执行，调用方需持有 s.mu
+func (s *scheduler) start(ctx context.Context, e *entry, now time.Time) {
+	job := e.job
+	if len(e.running) > 0 {
+		switch job.Overlap {
+		case OverlapSkip:
+			logit.Context(ctx).WarnW("logType", "cron job skipped", "job", job.Name, "reason", "previous run still running")
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				appendHistory(ctx, s.cfg, Record{Job: job.Name, Method: job.Method, StartTime: now, Code: "OK", Skipped: true})
+			}()
+			return
+		case OverlapReplace:
+			for _, cancel := range e.running {
+				cancel()
+			}
+		}
+	}
+
+	runTaskID := max(uint64(now.UnixNano()), s.lastRunTaskID+1)
+	s.lastRunTaskID = runTaskID
+	runCtx, cancel := context.WithCancel(ctx)
+	e.running[runTaskID] = cancel
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		record := s.run(runCtx, job, runTaskID)
+
+		s.mu.Lock()
+		delete(e.running, runTaskID)
+		s.mu.Unlock()
+		cancel()
+
+		appendHistory(ctx, s.cfg, record)
+	}()
+}
+
+// run 执行一次任务，输出写入输出目录
+func (s *scheduler) run(ctx context.Context, job *parsedJob, runTaskID uint64) Record {
+	record := Record{Job: job.Name, RunTaskID: runTaskID, Method: job.Method, StartTime: time.Now()}
+
+	err := func() error {
+		outputPath := filepath.Join(s.cfg.OutputDir, job.Name, fmt.Sprintf("%d.jsonl", runTaskID))
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0o750); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o640)
+		if err != nil {
+			return err
+		}
+		record.Output = outputPath
+		defer func() {
+			if errC := f.Close(); errC != nil {
+				logit.Context(ctx).WarnW("cron.output.Close.Err", errC)
+			}
+		}()
+
+		return s.exec.Execute(ctx, &pb.TaskRequest{
+			MethodName:    job.Method,
+			MethodParams:  job.Params,
+			Timeout:       job.TimeoutSec,
+			RunTaskId:     runTaskID,
+			Signature:     job.signature,
+			KeyId:         job.KeyID,
+			ParamsVersion: job.ParamsVersion,
+		}, executor.NewJSONLinesSink(f))
+	}()
+
+	st := status.Convert(err)
+	record.DurationMs = time.Since(record.StartTime).Milliseconds()
+	record.Code = st.Code().String()
+	record.Error = st.Message()
+	record.ExitCode = executor.ExitCode(err)
+	return record
+}
@@ -0,0 +1,104 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"goumang-worker/services/cron/config"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+)
+
+// Record 一条执行历史
+type Record struct {
+	Job       string    `json:"job"`
+	RunTaskID uint64    `json:"runTaskId,omitempty"`
+	Method    string    `json:"method"`
+	StartTime time.Time `json:"startTime"`
+	// 耗时毫秒数
+	DurationMs int64 `json:"durationMs"`
+	// gRPC 状态码名称，成功为 OK
+	Code     string `json:"code"`
+	Error    string `json:"error,omitempty"`
+	ExitCode int32  `json:"exitCode"`
+	// 输出文件路径
+	Output string `json:"output,omitempty"`
+	// 因上一次执行未结束而跳过
+	Skipped bool `json:"skipped,omitempty"`
+}
+
+var historyMu sync.Mutex
+
+// appendHistory 追加一条执行历史，文件超出大小上限时先轮转
+func appendHistory(ctx context.Context, cfg config.CronConfig, record Record) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		logit.Context(ctx).WarnW("cron.history.Marshal.Err", err)
+		return
+	}
+
+	historyMu.Lock()
+	defer historyMu.Unlock()
+
+	if err = os.MkdirAll(filepath.Dir(cfg.HistoryFile), 0o750); err != nil {
+		logit.Context(ctx).WarnW("cron.history.MkdirAll.Err", err)
+		return
+	}
+	if cfg.HistoryMaxBytes > 0 {
+		if info, errS := os.Stat(cfg.HistoryFile); errS == nil && info.Size()+int64(len(data)) >= cfg.HistoryMaxBytes {
+			if errR := os.Rename(cfg.HistoryFile, cfg.HistoryFile+".1"); errR != nil {
+				logit.Context(ctx).WarnW("cron.history.Rename.Err", errR)
+			}
+		}
+	}
+
+	f, err := os.OpenFile(cfg.HistoryFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		logit.Context(ctx).WarnW("cron.history.OpenFile.Err", err)
+		return
+	}
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		logit.Context(ctx).WarnW("cron.history.Write.Err", err)
+	}
+	if err = f.Close(); err != nil {
+		logit.Context(ctx).WarnW("cron.history.Close.Err", err)
+	}
+}
+
+// cleanOutput 删除修改时间早于保留期的输出文件
+func cleanOutput(ctx context.Context, cfg config.CronConfig) {
+	if cfg.OutputRetentionHours <= 0 {
+		return
+	}
+	jobDirs, err := os.ReadDir(cfg.OutputDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logit.Context(ctx).WarnW("cron.output.ReadDir.Err", err)
+		}
+		return
+	}
+
+	expireBefore := time.Now().Add(-time.Duration(cfg.OutputRetentionHours) * time.Hour)
+	for _, jobDir := range jobDirs {
+		if !jobDir.IsDir() {
+			continue
+		}
+		dir := filepath.Join(cfg.OutputDir, jobDir.Name())
+		entries, errR := os.ReadDir(dir)
+		if errR != nil {
+			continue
+		}
+		for _, entry := range entries {
+			info, errI := entry.Info()
+			if errI != nil || !info.Mode().IsRegular() || !info.ModTime().Before(expireBefore) {
+				continue
+			}
+			if errRm := os.Remove(filepath.Join(dir, entry.Name())); errRm != nil {
+				logit.Context(ctx).WarnW("cron.output.Remove.Err", errRm)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package cron
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+	"github.com/bpcoder16/Chestnut/v2/logit"
+)
+
+// 上一次执行未结束时的策略
+const (
+	// OverlapSkip 跳过本次执行（默认）
+	OverlapSkip = "skip"
+	// OverlapAllow 与上一次并行执行
+	OverlapAllow = "allow"
+	// OverlapReplace 取消上一次后执行
+	OverlapReplace = "replace"
+)
+
+// jobNamePattern 任务名同时用作输出目录名
+var jobNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Job 任务定义文件中的一个任务
+type Job struct {
+	// 任务名，唯一
+	Name string `yaml:"name"`
+	// cron 表达式
+	Schedule string `yaml:"schedule"`
+	// 方法名
+	Method string `yaml:"method"`
+	// 方法参数
+	Params string `yaml:"params"`
+	// 超时秒数，0 表示使用默认超时
+	TimeoutSec int32 `yaml:"timeoutSec"`
+	// 上一次执行未结束时的策略
+	Overlap string `yaml:"overlap"`
+	// 参数版本，0 表示不校验
+	ParamsVersion uint32 `yaml:"paramsVersion"`
+	// base64 编码的任务签名
+	Signature string `yaml:"signature"`
+	// 签名密钥 ID
+	KeyID string `yaml:"keyId"`
+}
+
+// scheduleFile 任务定义文件结构
+type scheduleFile struct {
+	Jobs []Job `yaml:"jobs"`
+}
+
+// parsedJob 校验通过的任务
+type parsedJob struct {
+	Job
+	schedule  Schedule
+	signature []byte
+}
+
+// loadSchedule 解析任务定义文件，文件格式错误时返回错误，单个任务定义错误时跳过该任务
+func loadSchedule(ctx context.Context, filePath string) (map[string]*parsedJob, error) {
+	var file scheduleFile
+	if err := utils.ParseFile(filePath, &file); err != nil {
+		return nil, err
+	}
+
+	jobs := make(map[string]*parsedJob, len(file.Jobs))
+	for _, job := range file.Jobs {
+		parsed, err := parseJob(job)
+		if err == nil && jobs[job.Name] != nil {
+			err = fmt.Errorf("duplicate job name")
+		}
+		if err != nil {
+			logit.Context(ctx).WarnW("logType", "cron job skipped", "job", job.Name, "reason", err.Error())
+			continue
+		}
+		jobs[job.Name] = parsed
+	}
+	return jobs, nil
+}
+
+// parseJob 校验单个任务定义
+func parseJob(job Job) (*parsedJob, error) {
+	if !jobNamePattern.MatchString(job.Name) {
+		return nil, fmt.Errorf("invalid job name %q", job.Name)
+	}
+	if job.Method == "" {
+		return nil, fmt.Errorf("method is required")
+	}
+	if job.TimeoutSec < 0 {
+		return nil, fmt.Errorf("negative timeoutSec")
+	}
+	switch job.Overlap {
+	case "":
+		job.Overlap = OverlapSkip
+	case OverlapSkip, OverlapAllow, OverlapReplace:
+	default:
+		return nil, fmt.Errorf("invalid overlap policy %q", job.Overlap)
+	}
+
+	schedule, err := Parse(job.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %v", job.Schedule, err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(job.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %v", err)
+	}
+	return &parsedJob{Job: job, schedule: schedule, signature: signature}, nil
+}
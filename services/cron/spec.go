@@ -0,0 +1,187 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule 计算下一次触发时间
+type Schedule interface {
+	// Next 返回晚于 t 的下一次触发时间，永不触发时返回零值
+	Next(t time.Time) time.Time
+}
+
+// maxSearchYears 查找下一次触发时间的年数上限，用于 2 月 30 日等永不匹配的表达式
+const maxSearchYears = 5
+
+// macros 预定义表达式
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field 表达式单个字段的取值范围
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	// 0 与 7 都表示周日
+	{"day of week", 0, 7},
+}
+
+// Parse 解析五段式 cron 表达式（分 时 日 月 周），支持 *、a-b、*/n、a-b/n、逗号列表、
+// @hourly 等预定义表达式与 "@every <duration>"
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if rest, ok := strings.CutPrefix(spec, "@every "); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("invalid @every duration: %v", err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("@every duration must be at least 1s")
+		}
+		return everySchedule(d), nil
+	}
+	if expanded, ok := macros[spec]; ok {
+		spec = expanded
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("expected %d fields, got %d", len(fields), len(parts))
+	}
+	var bits [5]uint64
+	for i, part := range parts {
+		b, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		bits[i] = b
+	}
+
+	s := &cronSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		// 日与周都受限时满足任一即可
+		domStar: parts[2] == "*" || strings.HasPrefix(parts[2], "*/"),
+		dowStar: parts[4] == "*" || strings.HasPrefix(parts[4], "*/"),
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parseField 解析单个字段为位图
+func parseField(text string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(text, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s: invalid step %q", f.name, stepPart)
+			}
+			step = n
+		}
+
+		low, high := f.min, f.max
+		if rangePart != "*" {
+			lowText, highText, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseValue(lowText, f); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = parseValue(highText, f); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				// "a/n" 表示从 a 开始到最大值
+				high = f.max
+			}
+			if low > high {
+				return 0, fmt.Errorf("%s: invalid range %q", f.name, rangePart)
+			}
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// parseValue 解析字段中的单个数值
+func parseValue(text string, f field) (int, error) {
+	v, err := strconv.Atoi(text)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s: value %q out of range %d-%d", f.name, text, f.min, f.max)
+	}
+	return v, nil
+}
+
+// cronSchedule 五段式表达式，各字段为取值位图
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+// Next 实现 Schedule，逐级跳过不匹配的月、日、时、分
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches 判断日期是否匹配日与周字段
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// everySchedule 固定间隔
+type everySchedule time.Duration
+
+// Next 实现 Schedule，按间隔对齐到整秒
+func (e everySchedule) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e)).Truncate(time.Second)
+}
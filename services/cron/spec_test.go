@@ -0,0 +1,72 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNext(t *testing.T) {
+	// 2026-10-18 为周日
+	base := time.Date(2026, 10, 18, 10, 30, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", at(10, 18, 10, 31)},
+		{"*/15 * * * *", at(10, 18, 10, 45)},
+		{"5/20 * * * *", at(10, 18, 10, 45)},
+		{"@hourly", at(10, 18, 11, 0)},
+		{"30 10 * * *", at(10, 19, 10, 30)},
+		{"0,30 8-9 * * *", at(10, 19, 8, 0)},
+		{"0 9 * * 1-5", at(10, 19, 9, 0)},
+		{"0 9 * * 7", at(10, 25, 9, 0)},
+		{"0 0 1 * *", at(11, 1, 0, 0)},
+		// 日与周都受限时满足任一即可：10-23 为周五
+		{"0 0 13 * 5", at(10, 23, 0, 0)},
+		{"@yearly", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+		{"@every 90s", base.Add(90 * time.Second)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.spec, err)
+			}
+			if got := s.Next(base); !got.Equal(tt.want) {
+				t.Errorf("Parse(%q).Next = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"a * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"@every 500ms",
+		"@every soon",
+		"@often",
+	}
+	for _, spec := range specs {
+		t.Run(spec, func(t *testing.T) {
+			if _, err := Parse(spec); err == nil {
+				t.Errorf("Parse(%q) succeeded, want error", spec)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// Root 允许打包与解压的目录根
+type Root struct {
+	// 请求中使用的名称
+	Name string `yaml:"name"`
+	// 目录绝对路径
+	Path string `yaml:"path"`
+	// 是否允许写入
+	Writable bool `yaml:"writable"`
+}
+
+// ArchiveExecutorConfig 归档执行器配置
+type ArchiveExecutorConfig struct {
+	Roots []Root `yaml:"roots"`
+	// 单次最多处理的条目数
+	MaxFiles int `yaml:"maxFiles"`
+	// 内容总字节数上限，0 表示不限制
+	MaxTotalBytes int64 `yaml:"maxTotalBytes"`
+	// 解压内容与归档大小的最大比例，0 表示不限制
+	MaxRatio float64 `yaml:"maxRatio"`
+	// 是否保留符号链接
+	AllowSymlinks bool `yaml:"allowSymlinks"`
+	// 进度事件最小发送间隔（毫秒）
+	ProgressIntervalMs int `yaml:"progressIntervalMs"`
+}
+
+// Config 归档配置结构
+type Config struct {
+	Archive ArchiveExecutorConfig `yaml:"archive"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "archive.yaml"), &globalConfig); err != nil {
+			panic("loadConfig archive.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if globalConfig.Archive.MaxFiles <= 0 {
+			globalConfig.Archive.MaxFiles = 10000
+		}
+		if globalConfig.Archive.ProgressIntervalMs <= 0 {
+			globalConfig.Archive.ProgressIntervalMs = 500
+		}
+	})
+}
+
+// GetArchiveConfig 获取归档执行器配置
+func GetArchiveConfig() ArchiveExecutorConfig {
+	lazyLoadConfig()
+	return globalConfig.Archive
+}
+
+// GetRoot 按名称查找目录根
+func GetRoot(name string) (Root, bool) {
+	for _, root := range GetArchiveConfig().Roots {
+		if root.Name == name {
+			return root, true
+		}
+	}
+	return Root{}, false
+}
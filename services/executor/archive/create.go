@@ -0,0 +1,178 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/archive/config"
+	"goumang-worker/services/internal/pathguard"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// item 待打包的条目
+type item struct {
+	rel  string
+	path string
+	info fs.FileInfo
+	link string
+}
+
+// collectItems 遍历源目录，按限制筛选条目，跳过越出目录的符号链接与特殊文件
+func collectItems(cfg config.ArchiveExecutorConfig, srcDir, skipPath string, sender *executor.OutputSender) ([]item, int64, error) {
+	var (
+		items []item
+		total int64
+	)
+	err := filepath.WalkDir(srcDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == srcDir || p == skipPath {
+			return nil
+		}
+		rel, _ := filepath.Rel(srcDir, p)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		it := item{rel: rel, path: p, info: info}
+		switch info.Mode().Type() {
+		case 0:
+			total += info.Size()
+			if cfg.MaxTotalBytes > 0 && total > cfg.MaxTotalBytes {
+				return fmt.Errorf("%w: content exceeds %d bytes", errLimitExceeded, cfg.MaxTotalBytes)
+			}
+		case fs.ModeDir:
+		case fs.ModeSymlink:
+			it.link, err = os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			if !cfg.AllowSymlinks || !pathguard.LinkWithin(srcDir, p, it.link) {
+				sender.SendLine(fmt.Sprintf("skip symlink: %s -> %s", filepath.ToSlash(rel), it.link), true)
+				return nil
+			}
+		default:
+			sender.SendLine("skip special file: "+filepath.ToSlash(rel), true)
+			return nil
+		}
+
+		if len(items) >= cfg.MaxFiles {
+			return fmt.Errorf("%w: more than %d entries", errLimitExceeded, cfg.MaxFiles)
+		}
+		items = append(items, it)
+		return nil
+	})
+	return items, total, err
+}
+
+// archiveWriter 归档写入器
+type archiveWriter interface {
+	add(it item) error
+	Close() error
+}
+
+// newArchiveWriter 按格式创建归档写入器
+func newArchiveWriter(format string, w io.Writer) archiveWriter {
+	switch format {
+	case formatZip:
+		return &zipWriter{zw: zip.NewWriter(w)}
+	case formatTarGz:
+		gz := gzip.NewWriter(w)
+		return &tarWriter{tw: tar.NewWriter(gz), gz: gz}
+	default:
+		return &tarWriter{tw: tar.NewWriter(w)}
+	}
+}
+
+// tarWriter tar/tar.gz 写入器
+type tarWriter struct {
+	tw *tar.Writer
+	gz *gzip.Writer
+}
+
+// add 写入单个条目
+func (t *tarWriter) add(it item) error {
+	hdr, err := tar.FileInfoHeader(it.info, it.link)
+	if err != nil {
+		return err
+	}
+	hdr.Name = filepath.ToSlash(it.rel)
+	if it.info.IsDir() {
+		hdr.Name += "/"
+	}
+	if err = t.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if !it.info.Mode().IsRegular() {
+		return nil
+	}
+	return copyFile(t.tw, it.path, it.info.Size())
+}
+
+// Close 结束归档
+func (t *tarWriter) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return err
+	}
+	if t.gz != nil {
+		return t.gz.Close()
+	}
+	return nil
+}
+
+// zipWriter zip 写入器
+type zipWriter struct {
+	zw *zip.Writer
+}
+
+// add 写入单个条目，符号链接的目标作为条目内容保存
+func (z *zipWriter) add(it item) error {
+	hdr, err := zip.FileInfoHeader(it.info)
+	if err != nil {
+		return err
+	}
+	hdr.Name = filepath.ToSlash(it.rel)
+	if it.info.IsDir() {
+		hdr.Name += "/"
+	} else if it.info.Mode().IsRegular() {
+		hdr.Method = zip.Deflate
+	}
+	w, err := z.zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	switch {
+	case it.info.Mode().IsRegular():
+		return copyFile(w, it.path, it.info.Size())
+	case it.link != "":
+		_, err = io.WriteString(w, it.link)
+		return err
+	default:
+		return nil
+	}
+}
+
+// Close 结束归档
+func (z *zipWriter) Close() error {
+	return z.zw.Close()
+}
+
+// copyFile 按遍历时的大小复制文件内容，防止打包过程中文件增长突破限制
+func copyFile(w io.Writer, p string, size int64) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	_, err = io.CopyN(w, f, size)
+	return err
+}
@@ -0,0 +1,279 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/archive/config"
+	"goumang-worker/services/pb"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// init 自动注册归档执行器到默认工厂
+func init() {
+	executor.RegisterExecutor(pb.Method_ARCHIVE.String(), NewExecutor)
+	executor.RegisterParams(pb.Method_ARCHIVE.String(), executor.ParamsSpec{
+		Version:     1,
+		Description: "Create or extract tar/zip archives between configured roots",
+		Params:      Params{},
+	})
+}
+
+const (
+	actionCreate  = "create"
+	actionExtract = "extract"
+
+	formatTar   = "tar"
+	formatTarGz = "tar.gz"
+	formatZip   = "zip"
+)
+
+// Params 归档参数（method_params 的 JSON 内容）
+type Params struct {
+	// create 或 extract
+	Action string `json:"action" schema:"required,enum=create|extract"`
+	// tar、tar.gz 或 zip，为空时按归档文件扩展名推断
+	Format string `json:"format"`
+	// 归档文件：create 时为输出，extract 时为输入
+	Archive Location `json:"archive" schema:"required"`
+	// 目录：create 时为打包来源，extract 时为解压目标
+	Dir Location `json:"dir" schema:"required"`
+	// 目标已存在时是否替换
+	Overwrite bool `json:"overwrite"`
+}
+
+// Executor 归档执行器
+type Executor struct{}
+
+// NewExecutor 创建新的归档执行器
+func NewExecutor() executor.Executor {
+	return &Executor{}
+}
+
+// Execute 打包或解压，逐条输出条目路径并以事件上报进度
+func (e *Executor) Execute(ctx context.Context, params string, sink executor.Sink) error {
+	p, err := executor.DecodeParams[Params](ctx, params)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid params: %v", err))
+	}
+	format, err := detectFormat(p.Format, p.Archive.Path)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	cfg := config.GetArchiveConfig()
+	sender := executor.NewOutputSender(sink)
+	var files int
+	var bytes int64
+	switch p.Action {
+	case actionCreate:
+		files, bytes, err = e.create(ctx, cfg, *p, format, sender)
+	case actionExtract:
+		files, bytes, err = e.extract(ctx, cfg, *p, format, sender)
+	default:
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("unknown action: %q", p.Action))
+	}
+	if err != nil {
+		return e.archiveError(ctx, err)
+	}
+
+	sender.SendEvent(&pb.TaskEvent{Event: &pb.TaskEvent_Metrics{Metrics: &pb.Metrics{Values: map[string]string{
+		"files": strconv.Itoa(files),
+		"bytes": strconv.FormatInt(bytes, 10),
+	}}}})
+	if errS := sender.Err(); errS != nil {
+		logit.Context(ctx).WarnW("stream.Send.Err", errS)
+		return status.Error(codes.Internal, fmt.Sprintf("failed to send output: %v", errS))
+	}
+	if errS := sink.Result(sender.Result()); errS != nil {
+		logit.Context(ctx).WarnW("result.stream.Send.Err", errS)
+	}
+	return nil
+}
+
+// create 打包目录，先写入同目录临时文件，完成后原子重命名
+func (e *Executor) create(ctx context.Context, cfg config.ArchiveExecutorConfig, p Params, format string, sender *executor.OutputSender) (int, int64, error) {
+	srcDir, err := resolveExisting(p.Dir, true)
+	if err != nil {
+		return 0, 0, err
+	}
+	target, exists, err := resolveTarget(p.Archive)
+	if err != nil {
+		return 0, 0, err
+	}
+	if exists && !p.Overwrite {
+		return 0, 0, fmt.Errorf("%w: %q", errExists, p.Archive.Path)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".goumang-*")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	// 归档输出在源目录内时跳过临时文件自身
+	items, total, err := collectItems(cfg, srcDir, tmp.Name(), sender)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	w := newArchiveWriter(format, tmp)
+	prog := newProgress(sender, time.Duration(cfg.ProgressIntervalMs)*time.Millisecond, int64(len(items)))
+	for i, it := range items {
+		if ctx.Err() != nil {
+			return 0, 0, ctx.Err()
+		}
+		if err = w.add(it); err != nil {
+			return 0, 0, fmt.Errorf("add %q: %w", filepath.ToSlash(it.rel), err)
+		}
+		sender.SendLine(filepath.ToSlash(it.rel), false)
+		prog.update(int64(i + 1))
+	}
+	if err = w.Close(); err != nil {
+		return 0, 0, err
+	}
+	if err = tmp.Chmod(0o644); err != nil {
+		return 0, 0, err
+	}
+	if err = tmp.Close(); err != nil {
+		return 0, 0, err
+	}
+	if err = os.Rename(tmp.Name(), target); err != nil {
+		return 0, 0, err
+	}
+	return len(items), total, nil
+}
+
+// extract 解压到全新的暂存目录，全部成功后再替换目标目录，失败时不留下半成品
+func (e *Executor) extract(ctx context.Context, cfg config.ArchiveExecutorConfig, p Params, format string, sender *executor.OutputSender) (int, int64, error) {
+	archivePath, err := resolveExisting(p.Archive, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	target, exists, err := resolveTarget(p.Dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	if exists && !p.Overwrite {
+		return 0, 0, fmt.Errorf("%w: %q", errExists, p.Dir.Path)
+	}
+
+	staging, err := os.MkdirTemp(filepath.Dir(target), "."+filepath.Base(target)+".goumang-*")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		_ = os.RemoveAll(staging)
+	}()
+
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ex := newExtractor(ctx, cfg, staging, info.Size(), sender)
+	prog := newProgress(sender, time.Duration(cfg.ProgressIntervalMs)*time.Millisecond, info.Size())
+	switch format {
+	case formatZip:
+		err = ex.extractZip(archivePath, prog)
+	default:
+		err = ex.extractTar(file, format == formatTarGz, prog)
+	}
+	if err == nil {
+		err = ex.pruneLinks()
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err = os.Chmod(staging, 0o755); err != nil {
+		return 0, 0, err
+	}
+	if !exists {
+		if err = os.Rename(staging, target); err != nil {
+			return 0, 0, err
+		}
+		return ex.files, ex.bytes, nil
+	}
+
+	// 先移走原目录，替换失败时还原，成功后才删除备份
+	backup := staging + ".old"
+	if err = os.Rename(target, backup); err != nil {
+		return 0, 0, err
+	}
+	if err = os.Rename(staging, target); err != nil {
+		if errR := os.Rename(backup, target); errR != nil {
+			logit.Context(ctx).WarnW("archive.restore.Err", errR, "backup", backup)
+		}
+		return 0, 0, err
+	}
+	if errR := os.RemoveAll(backup); errR != nil {
+		logit.Context(ctx).WarnW("archive.RemoveAll.Err", errR, "backup", backup)
+	}
+	return ex.files, ex.bytes, nil
+}
+
+// archiveError 转换归档错误为 gRPC 状态
+func (e *Executor) archiveError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("archive canceled or timeout: %v", ctx.Err()))
+	}
+	switch {
+	case errors.Is(err, errUnknownRoot), errors.Is(err, errReadOnly):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, errUnsafePath), errors.Is(err, errCorrupt):
+		logit.Context(ctx).WarnW("logType", "archive rejected", "reason", err.Error())
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, errLimitExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		logit.Context(ctx).WarnW("archive.Err", err)
+		return status.Error(codes.Internal, fmt.Sprintf("archive failed: %v", err))
+	}
+}
+
+// detectFormat 校验格式，未指定时按扩展名推断
+func detectFormat(format, archivePath string) (string, error) {
+	if format == "" {
+		lower := strings.ToLower(archivePath)
+		switch {
+		case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+			format = formatTarGz
+		case strings.HasSuffix(lower, ".tar"):
+			format = formatTar
+		case strings.HasSuffix(lower, ".zip"):
+			format = formatZip
+		default:
+			return "", fmt.Errorf("cannot detect archive format of %q", archivePath)
+		}
+	}
+	switch format {
+	case formatTar, formatTarGz, formatZip:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported archive format: %q", format)
+	}
+}
@@ -0,0 +1,263 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/archive/config"
+	"goumang-worker/services/internal/pathguard"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// maxLinkBytes zip 中符号链接目标的最大长度
+const maxLinkBytes = 4096
+
+// extractor 将归档条目写入全新的暂存目录，并执行数量、大小与压缩比限制
+type extractor struct {
+	ctx    context.Context
+	cfg    config.ArchiveExecutorConfig
+	dir    string
+	sender *executor.OutputSender
+	// 剩余可写入字节数，小于 0 表示不限制
+	budget int64
+	files  int
+	bytes  int64
+}
+
+// newExtractor 创建解压器，总字节上限取总大小限制与压缩比限制中较小的一个
+func newExtractor(ctx context.Context, cfg config.ArchiveExecutorConfig, dir string, archiveSize int64, sender *executor.OutputSender) *extractor {
+	budget := int64(-1)
+	if cfg.MaxTotalBytes > 0 {
+		budget = cfg.MaxTotalBytes
+	}
+	if cfg.MaxRatio > 0 {
+		if byRatio := int64(cfg.MaxRatio * float64(archiveSize)); budget < 0 || byRatio < budget {
+			budget = byRatio
+		}
+	}
+	return &extractor{ctx: ctx, cfg: cfg, dir: dir, sender: sender, budget: budget}
+}
+
+// extractTar 解压 tar 或 tar.gz
+func (e *extractor) extractTar(r io.Reader, gz bool, prog *progress) error {
+	counter := &countingReader{r: r}
+	r = counter
+	if gz {
+		gzr, err := gzip.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errCorrupt, err)
+		}
+		defer func() {
+			_ = gzr.Close()
+		}()
+		r = gzr
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", errCorrupt, err)
+		}
+
+		mode := hdr.FileInfo().Mode()
+		switch hdr.Typeflag {
+		case tar.TypeXGlobalHeader:
+			continue
+		case tar.TypeLink:
+			// 硬链接不保留
+			mode = fs.ModeIrregular
+		}
+		if err = e.add(hdr.Name, mode, hdr.Linkname, tr); err != nil {
+			return err
+		}
+		prog.update(counter.n)
+	}
+}
+
+// extractZip 解压 zip
+func (e *extractor) extractZip(archivePath string, prog *progress) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errCorrupt, err)
+	}
+	defer func() {
+		_ = zr.Close()
+	}()
+
+	prog.total = int64(len(zr.File))
+	for i, f := range zr.File {
+		if err = e.addZipFile(f); err != nil {
+			return err
+		}
+		prog.update(int64(i + 1))
+	}
+	return nil
+}
+
+// addZipFile 解压单个 zip 条目，符号链接的目标保存在条目内容中
+func (e *extractor) addZipFile(f *zip.File) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %v", errCorrupt, err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	var linkname string
+	if f.Mode()&fs.ModeSymlink != 0 {
+		link, errR := io.ReadAll(io.LimitReader(rc, maxLinkBytes))
+		if errR != nil {
+			return fmt.Errorf("%w: %v", errCorrupt, errR)
+		}
+		linkname = string(link)
+	}
+	return e.add(f.Name, f.Mode(), linkname, rc)
+}
+
+// add 写入单个条目
+func (e *extractor) add(name string, mode fs.FileMode, linkname string, r io.Reader) error {
+	if err := e.ctx.Err(); err != nil {
+		return err
+	}
+	rel, ok := pathguard.CleanEntryName(name)
+	if !ok {
+		return fmt.Errorf("%w: entry %q", errUnsafePath, name)
+	}
+	if rel == "" {
+		return nil
+	}
+	e.files++
+	if e.files > e.cfg.MaxFiles {
+		return fmt.Errorf("%w: more than %d entries", errLimitExceeded, e.cfg.MaxFiles)
+	}
+	target := filepath.Join(e.dir, rel)
+
+	var err error
+	switch mode.Type() {
+	case fs.ModeDir:
+		if err = e.prepareParent(target); err != nil {
+			return err
+		}
+		if err = os.MkdirAll(target, 0o755); err != nil {
+			return err
+		}
+		if err = e.checkWithin(target, rel); err != nil {
+			return err
+		}
+	case 0:
+		if err = e.prepareParent(target); err != nil {
+			return err
+		}
+		if err = e.writeFile(target, rel, mode.Perm(), r); err != nil {
+			return err
+		}
+	case fs.ModeSymlink:
+		if !e.cfg.AllowSymlinks || !pathguard.LinkWithin(e.dir, target, linkname) {
+			e.sender.SendLine(fmt.Sprintf("skip symlink: %s -> %s", filepath.ToSlash(rel), linkname), true)
+			return nil
+		}
+		if err = e.prepareParent(target); err != nil {
+			return err
+		}
+		if err = os.Symlink(linkname, target); err != nil {
+			return err
+		}
+	default:
+		e.sender.SendLine("skip special file: "+filepath.ToSlash(rel), true)
+		return nil
+	}
+
+	e.sender.SendLine(filepath.ToSlash(rel), false)
+	return nil
+}
+
+// prepareParent 创建父目录并确认其真实路径仍在暂存目录内，同名的非目录条目将被后者覆盖
+func (e *extractor) prepareParent(target string) error {
+	parent := filepath.Dir(target)
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return err
+	}
+	rel, _ := filepath.Rel(e.dir, target)
+	if err := e.checkWithin(parent, rel); err != nil {
+		return err
+	}
+	if info, err := os.Lstat(target); err == nil && !info.IsDir() {
+		return os.Remove(target)
+	}
+	return nil
+}
+
+// checkWithin 确认路径解析符号链接后仍在暂存目录内
+func (e *extractor) checkWithin(p, rel string) error {
+	realPath, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		return err
+	}
+	if !pathguard.IsWithin(e.dir, realPath) {
+		return fmt.Errorf("%w: entry %q", errUnsafePath, filepath.ToSlash(rel))
+	}
+	return nil
+}
+
+// pruneLinks 解压完成后按真实路径复查符号链接，链式链接可能绕过按字面路径的检查
+func (e *extractor) pruneLinks() error {
+	if !e.cfg.AllowSymlinks {
+		return nil
+	}
+	return filepath.WalkDir(e.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.Type()&fs.ModeSymlink == 0 {
+			return err
+		}
+		realPath, errE := filepath.EvalSymlinks(p)
+		if errE == nil && pathguard.IsWithin(e.dir, realPath) {
+			return nil
+		}
+		rel, _ := filepath.Rel(e.dir, p)
+		e.sender.SendLine("remove dangling or escaping symlink: "+filepath.ToSlash(rel), true)
+		return os.Remove(p)
+	})
+}
+
+// writeFile 写入普通文件，超出剩余额度时中止
+func (e *extractor) writeFile(target, rel string, perm fs.FileMode, r io.Reader) (err error) {
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errC := f.Close(); err == nil {
+			err = errC
+		}
+	}()
+
+	var n int64
+	if e.budget < 0 {
+		n, err = io.Copy(f, r)
+	} else {
+		n, err = io.CopyN(f, r, e.budget+1)
+		if err == io.EOF {
+			err = nil
+		}
+		if err == nil && n > e.budget {
+			return fmt.Errorf("%w: extracted size exceeds %d bytes at %q", errLimitExceeded, e.bytes+e.budget, filepath.ToSlash(rel))
+		}
+		e.budget -= n
+	}
+	e.bytes += n
+	if errors.Is(err, zip.ErrChecksum) || errors.Is(err, gzip.ErrChecksum) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: %q: %v", errCorrupt, filepath.ToSlash(rel), err)
+	}
+	return err
+}
@@ -0,0 +1,117 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor/archive/config"
+	"goumang-worker/services/internal/pathguard"
+	"os"
+	"path/filepath"
+)
+
+var (
+	// errUnknownRoot 目录根未配置
+	errUnknownRoot = errors.New("unknown root")
+	// errReadOnly 目录根不允许写入
+	errReadOnly = errors.New("root is read-only")
+	// errUnsafePath 路径越出目录（绝对路径、".." 或符号链接逃逸）
+	errUnsafePath = errors.New("unsafe path")
+	// errNotFound 文件或目录不存在
+	errNotFound = errors.New("not found")
+	// errExists 目标已存在且未允许覆盖
+	errExists = errors.New("target already exists")
+	// errLimitExceeded 超出条目数、总大小或压缩比限制
+	errLimitExceeded = errors.New("archive limit exceeded")
+	// errCorrupt 归档格式错误
+	errCorrupt = errors.New("corrupt archive")
+)
+
+// Location 目录根内的位置
+type Location struct {
+	// 配置中的目录根名称
+	Root string `json:"root" schema:"required,minLength=1"`
+	// 根目录内的相对路径，"." 表示根目录本身
+	Path string `json:"path"`
+}
+
+// realRoot 查找目录根并返回解析符号链接后的路径
+func realRoot(loc Location, write bool) (string, error) {
+	root, ok := config.GetRoot(loc.Root)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", errUnknownRoot, loc.Root)
+	}
+	if write && !root.Writable {
+		return "", fmt.Errorf("%w: %q", errReadOnly, loc.Root)
+	}
+	return filepath.EvalSymlinks(root.Path)
+}
+
+// resolveExisting 解析已存在的文件或目录，结果必须位于目录根内
+func resolveExisting(loc Location, wantDir bool) (string, error) {
+	rootPath, err := realRoot(loc, false)
+	if err != nil {
+		return "", err
+	}
+	rel, ok := pathguard.CleanRelPath(loc.Path, wantDir)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", errUnsafePath, loc.Path)
+	}
+
+	realPath, err := filepath.EvalSymlinks(filepath.Join(rootPath, rel))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %q", errNotFound, loc.Path)
+		}
+		return "", err
+	}
+	if !pathguard.IsWithin(rootPath, realPath) {
+		return "", fmt.Errorf("%w: %q", errUnsafePath, loc.Path)
+	}
+	info, err := os.Stat(realPath)
+	if err != nil {
+		return "", err
+	}
+	if wantDir && !info.IsDir() {
+		return "", fmt.Errorf("%w: %q is not a directory", errNotFound, loc.Path)
+	}
+	if !wantDir && !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%w: %q is not a regular file", errNotFound, loc.Path)
+	}
+	return realPath, nil
+}
+
+// resolveTarget 解析写入目标，父目录必须已存在且位于可写目录根内；目标本身不能是符号链接
+func resolveTarget(loc Location) (target string, exists bool, err error) {
+	rootPath, err := realRoot(loc, true)
+	if err != nil {
+		return "", false, err
+	}
+	rel, ok := pathguard.CleanRelPath(loc.Path, false)
+	if !ok {
+		return "", false, fmt.Errorf("%w: %q", errUnsafePath, loc.Path)
+	}
+
+	parent, err := filepath.EvalSymlinks(filepath.Dir(filepath.Join(rootPath, rel)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, fmt.Errorf("%w: parent directory of %q", errNotFound, loc.Path)
+		}
+		return "", false, err
+	}
+	if !pathguard.IsWithin(rootPath, parent) {
+		return "", false, fmt.Errorf("%w: %q", errUnsafePath, loc.Path)
+	}
+
+	target = filepath.Join(parent, filepath.Base(rel))
+	info, err := os.Lstat(target)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return target, false, nil
+		}
+		return "", false, err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return "", false, fmt.Errorf("%w: %q is a symlink", errUnsafePath, loc.Path)
+	}
+	return target, true, nil
+}
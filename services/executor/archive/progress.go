@@ -0,0 +1,52 @@
+package archive
+
+import (
+	"goumang-worker/services/executor"
+	"goumang-worker/services/pb"
+	"io"
+	"time"
+)
+
+// progress 按最小间隔发送进度事件
+type progress struct {
+	sender   *executor.OutputSender
+	interval time.Duration
+	total    int64
+	last     time.Time
+	percent  float64
+}
+
+// newProgress 创建进度上报器，total 为 0 时不发送进度
+func newProgress(sender *executor.OutputSender, interval time.Duration, total int64) *progress {
+	return &progress{sender: sender, interval: interval, total: total, percent: -1}
+}
+
+// update 更新已处理量，达到间隔且百分比变化时发送事件
+func (p *progress) update(done int64) {
+	if p.total <= 0 {
+		return
+	}
+	percent := float64(done * 100 / p.total)
+	if percent > 100 {
+		percent = 100
+	}
+	if percent == p.percent || (percent < 100 && time.Since(p.last) < p.interval) {
+		return
+	}
+	p.last = time.Now()
+	p.percent = percent
+	p.sender.SendEvent(&pb.TaskEvent{Event: &pb.TaskEvent_Progress{Progress: percent}})
+}
+
+// countingReader 统计已读取的字节数，用于按归档读取量计算进度
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read 实现 io.Reader
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
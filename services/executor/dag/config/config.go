@@ -0,0 +1,55 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// DAGExecutorConfig DAG 执行器配置
+type DAGExecutorConfig struct {
+	// 单个 DAG 最多节点数
+	MaxNodes int `yaml:"maxNodes"`
+	// 最大并行节点数
+	MaxParallel int `yaml:"maxParallel"`
+	// 共享工作目录的根目录
+	WorkspaceRoot string `yaml:"workspaceRoot"`
+}
+
+// Config DAG 配置结构
+type Config struct {
+	DAG DAGExecutorConfig `yaml:"dag"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "dag.yaml"), &globalConfig); err != nil {
+			panic("loadConfig dag.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if globalConfig.DAG.MaxNodes <= 0 {
+			globalConfig.DAG.MaxNodes = 100
+		}
+		if globalConfig.DAG.MaxParallel <= 0 {
+			globalConfig.DAG.MaxParallel = 4
+		}
+		if globalConfig.DAG.WorkspaceRoot == "" {
+			globalConfig.DAG.WorkspaceRoot = path.Join(env.RootPath(), "data", "dags")
+		}
+	})
+}
+
+// GetDAGConfig 获取 DAG 执行器配置
+func GetDAGConfig() DAGExecutorConfig {
+	lazyLoadConfig()
+	return globalConfig.DAG
+}
@@ -0,0 +1,263 @@
+package dag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"goumang-worker/services/artifact"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/dag/config"
+	"goumang-worker/services/executor/step"
+	"goumang-worker/services/pb"
+	"os"
+	"strconv"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// init 自动注册 DAG 执行器到默认工厂
+func init() {
+	executor.RegisterExecutor(pb.Method_DAG.String(), NewExecutor)
+	executor.RegisterParams(pb.Method_DAG.String(), executor.ParamsSpec{
+		Version:     1,
+		Description: "Nodes run in dependency order with independent nodes in parallel",
+		Params:      Params{},
+	})
+}
+
+// Params DAG 参数（method_params 的 JSON 内容）
+type Params struct {
+	Nodes []Node `json:"nodes" schema:"required"`
+	// 所有节点共享的环境变量
+	Env map[string]string `json:"env"`
+	// 最大并行节点数，0 或超过配置时使用配置值
+	MaxParallel int `json:"maxParallel" schema:"min=0"`
+}
+
+// Node DAG 节点
+type Node struct {
+	// 节点名称，在 DAG 内唯一
+	Name string `json:"name" schema:"required,minLength=1"`
+	// 方法名，如 "SHELL"、"HTTP"
+	Method string `json:"method" schema:"required,minLength=1"`
+	// 方法参数：字符串原样传递，对象或数组按 JSON 文本传递
+	Params json.RawMessage `json:"params"`
+	// 节点超时秒数，0 表示只受整个任务超时限制
+	TimeoutSec int `json:"timeoutSec" schema:"min=0"`
+	// 依赖的节点名称
+	DependsOn []string `json:"dependsOn"`
+}
+
+// finished 节点执行完成通知
+type finished struct {
+	index int
+	out   *step.Outcome
+}
+
+// Executor DAG 执行器
+type Executor struct{}
+
+// NewExecutor 创建新的 DAG 执行器
+func NewExecutor() executor.Executor {
+	return &Executor{}
+}
+
+// Execute 按依赖关系执行节点：独立节点并行，失败节点的下游全部跳过，不相关的分支继续执行
+func (e *Executor) Execute(ctx context.Context, params string, sink executor.Sink) error {
+	p, err := executor.DecodeParams[Params](ctx, params)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid params: %v", err))
+	}
+	cfg := config.GetDAGConfig()
+	nodes, err := buildGraph(cfg, p.Nodes)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	width := cfg.MaxParallel
+	if p.MaxParallel > 0 && p.MaxParallel < width {
+		width = p.MaxParallel
+	}
+
+	// 共享工作目录
+	taskInfo := executor.TaskInfoFromContext(ctx)
+	if err = os.MkdirAll(cfg.WorkspaceRoot, 0o750); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("create workspace root failed: %v", err))
+	}
+	workDir, err := os.MkdirTemp(cfg.WorkspaceRoot, strconv.FormatUint(taskInfo.RunTaskID, 10)+"-")
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("create workspace failed: %v", err))
+	}
+	defer func() {
+		if errR := os.RemoveAll(workDir); errR != nil {
+			logit.Context(ctx).WarnW("dag.RemoveAll.Err", errR)
+		}
+	}()
+
+	// 产物在 DAG 结束后统一收集，节点内不重复收集
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	nodeCtx := executor.WithTaskInfo(runCtx, &executor.TaskInfo{
+		RunTaskID: taskInfo.RunTaskID,
+		WorkDir:   workDir,
+		Env:       append(append([]string{}, taskInfo.Env...), step.EnvList(p.Env)...),
+	})
+
+	r := &run{
+		sink:      sink,
+		nodes:     nodes,
+		remaining: make([]int, len(nodes)),
+		blocked:   make([]bool, len(nodes)),
+		result:    &pb.TaskResult{},
+		cancel:    cancel,
+	}
+	r.schedule(nodeCtx, width)
+
+	result := r.result
+	result.Artifacts = artifact.Collect(ctx, taskInfo.RunTaskID, workDir, taskInfo.Artifacts)
+	if r.sendErr == nil {
+		if errS := sink.Result(result); errS != nil {
+			logit.Context(ctx).WarnW("result.stream.Send.Err", errS)
+		}
+	}
+
+	switch {
+	case r.sendErr != nil:
+		return status.Error(codes.Internal, fmt.Sprintf("failed to send output: %v", r.sendErr))
+	case ctx.Err() != nil:
+		return status.Error(codes.Internal, fmt.Sprintf("dag canceled or timeout: %v", ctx.Err()))
+	case r.firstErr != nil:
+		return step.WrapError(fmt.Sprintf("node %s failed (%d failed, %d skipped)", r.firstFailed, r.failed, r.skipped), r.firstErr)
+	}
+	return nil
+}
+
+// run 一次 DAG 运行的调度状态，只在调度 goroutine 中修改
+type run struct {
+	sink      executor.Sink
+	nodes     []*node
+	remaining []int
+	blocked   []bool
+	result    *pb.TaskResult
+	cancel    context.CancelFunc
+
+	done        int
+	failed      int
+	skipped     int
+	firstFailed string
+	firstErr    error
+	sendErr     error
+}
+
+// schedule 按拓扑顺序调度节点，直到所有节点完成或跳过
+func (r *run) schedule(ctx context.Context, width int) {
+	var ready []int
+	for i, n := range r.nodes {
+		r.remaining[i] = len(n.deps)
+		if r.remaining[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	finishedCh := make(chan finished, len(r.nodes))
+	running := 0
+	for r.done < len(r.nodes) {
+		for len(ready) > 0 && running < width && ctx.Err() == nil {
+			i := ready[0]
+			ready = ready[1:]
+			r.sendState(&pb.NodeState{Node: r.nodes[i].name, Status: pb.NodeStatus_NODE_RUNNING})
+			running++
+			go func(i int) {
+				out := step.Run(ctx, r.nodes[i].inv, step.NewStream(r.sink, r.nodes[i].name))
+				finishedCh <- finished{index: i, out: out}
+			}(i)
+		}
+
+		// 被取消后不再启动新节点，尚未执行的节点全部跳过
+		if running == 0 {
+			for i := range r.nodes {
+				if r.remaining[i] >= 0 {
+					r.skip(i)
+				}
+			}
+			return
+		}
+
+		f := <-finishedCh
+		running--
+		ready = append(ready, r.finish(f)...)
+	}
+}
+
+// finish 记录节点结果，返回因此变为可执行的下游节点
+func (r *run) finish(f finished) []int {
+	n := r.nodes[f.index]
+	r.remaining[f.index] = -1
+	r.done++
+	step.MergeResult(r.result, f.out.Result)
+
+	state := &pb.NodeState{
+		Node:       n.name,
+		Status:     pb.NodeStatus_NODE_SUCCEEDED,
+		ExitCode:   f.out.ExitCode,
+		Error:      f.out.Error,
+		DurationMs: f.out.DurationMs,
+		Result:     f.out.Result,
+	}
+	if f.out.Err != nil {
+		state.Status = pb.NodeStatus_NODE_FAILED
+		r.failed++
+		if r.firstErr == nil {
+			r.firstFailed, r.firstErr = n.name, f.out.Err
+		}
+	}
+	r.sendState(state)
+	return r.release(f.index, f.out.Err != nil)
+}
+
+// skip 跳过节点，并继续向下游传播
+func (r *run) skip(i int) {
+	if r.remaining[i] < 0 {
+		return
+	}
+	r.remaining[i] = -1
+	r.done++
+	r.skipped++
+	r.sendState(&pb.NodeState{Node: r.nodes[i].name, Status: pb.NodeStatus_NODE_SKIPPED})
+	r.release(i, true)
+}
+
+// release 减少下游节点的待完成依赖数，上游失败或跳过的下游在依赖全部结束后被跳过
+func (r *run) release(i int, failed bool) []int {
+	var ready []int
+	for _, d := range r.nodes[i].dependents {
+		if r.remaining[d] < 0 {
+			continue
+		}
+		if failed {
+			r.blocked[d] = true
+		}
+		r.remaining[d]--
+		if r.remaining[d] > 0 {
+			continue
+		}
+		if r.blocked[d] {
+			r.skip(d)
+		} else {
+			ready = append(ready, d)
+		}
+	}
+	return ready
+}
+
+// sendState 发送节点状态事件，发送失败时取消整个 DAG
+func (r *run) sendState(state *pb.NodeState) {
+	if r.sendErr != nil {
+		return
+	}
+	if err := r.sink.Event(&pb.TaskEvent{Event: &pb.TaskEvent_NodeState{NodeState: state}}); err != nil {
+		r.sendErr = err
+		r.cancel()
+	}
+}
@@ -0,0 +1,116 @@
+package dag
+
+import (
+	"fmt"
+	"goumang-worker/services/executor/dag/config"
+	"goumang-worker/services/executor/step"
+	"goumang-worker/services/pb"
+	"strings"
+)
+
+// node 校验后的节点
+type node struct {
+	name       string
+	inv        step.Invocation
+	deps       []int
+	dependents []int
+}
+
+// buildGraph 校验节点名、依赖与方法，并确认图中无环
+func buildGraph(cfg config.DAGExecutorConfig, defs []Node) ([]*node, error) {
+	if len(defs) == 0 {
+		return nil, fmt.Errorf("no nodes")
+	}
+	if len(defs) > cfg.MaxNodes {
+		return nil, fmt.Errorf("too many nodes: %d > %d", len(defs), cfg.MaxNodes)
+	}
+
+	index := make(map[string]int, len(defs))
+	nodes := make([]*node, len(defs))
+	for i, def := range defs {
+		if def.Name == "" {
+			return nil, fmt.Errorf("node %d: name is required", i)
+		}
+		if _, exists := index[def.Name]; exists {
+			return nil, fmt.Errorf("duplicate node name %q", def.Name)
+		}
+		// 不允许嵌套编排
+		inv, err := step.Resolve(def.Method, def.Params, def.TimeoutSec, pb.Method_PIPELINE.String(), pb.Method_DAG.String())
+		if err != nil {
+			return nil, fmt.Errorf("node %q: %v", def.Name, err)
+		}
+		index[def.Name] = i
+		nodes[i] = &node{name: def.Name, inv: inv}
+	}
+
+	for i, def := range defs {
+		seen := make(map[int]bool, len(def.DependsOn))
+		for _, depName := range def.DependsOn {
+			dep, ok := index[depName]
+			if !ok {
+				return nil, fmt.Errorf("node %q depends on unknown node %q", def.Name, depName)
+			}
+			if dep == i {
+				return nil, fmt.Errorf("node %q depends on itself", def.Name)
+			}
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			nodes[i].deps = append(nodes[i].deps, dep)
+			nodes[dep].dependents = append(nodes[dep].dependents, i)
+		}
+	}
+
+	if cycle := findCycle(nodes); len(cycle) > 0 {
+		return nil, fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+	return nodes, nil
+}
+
+// findCycle 深度优先查找环，返回环上的节点名，无环时返回 nil
+func findCycle(nodes []*node) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(nodes))
+	var path []int
+
+	var visit func(i int) []string
+	visit = func(i int) []string {
+		state[i] = visiting
+		path = append(path, i)
+		for _, next := range nodes[i].dependents {
+			switch state[next] {
+			case visiting:
+				// 截取从 next 开始的路径即为环
+				var cycle []string
+				for j := len(path) - 1; j >= 0; j-- {
+					cycle = append([]string{nodes[path[j]].name}, cycle...)
+					if path[j] == next {
+						break
+					}
+				}
+				return append(cycle, nodes[next].name)
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+
+	for i := range nodes {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package dag
+
+import (
+	"context"
+	"encoding/json"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/dag/config"
+	"goumang-worker/services/pb"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testMethod 测试用方法：参数为 "fail" 时失败，否则成功，并记录执行顺序
+const testMethod = "DAG_TEST_NODE"
+
+var (
+	executedMu sync.Mutex
+	executed   []string
+)
+
+type testExecutor struct{}
+
+func (testExecutor) Execute(_ context.Context, params string, _ executor.Sink) error {
+	name, mode, _ := strings.Cut(params, ":")
+	executedMu.Lock()
+	executed = append(executed, name)
+	executedMu.Unlock()
+	if mode == "fail" {
+		return status.Error(codes.Unknown, name+" failed")
+	}
+	return nil
+}
+
+func init() {
+	executor.RegisterExecutor(testMethod, func() executor.Executor { return testExecutor{} })
+	executor.RegisterParams(testMethod, executor.ParamsSpec{Version: 1, Params: ""})
+}
+
+// recordSink 记录节点最终状态
+type recordSink struct {
+	mu     sync.Mutex
+	states map[string]pb.NodeStatus
+}
+
+func (s *recordSink) Stdout(string) error           { return nil }
+func (s *recordSink) Stderr(string) error           { return nil }
+func (s *recordSink) Heartbeat(*pb.Heartbeat) error { return nil }
+func (s *recordSink) Result(*pb.TaskResult) error   { return nil }
+func (s *recordSink) Event(event *pb.TaskEvent) error {
+	if state := event.GetNodeState(); state != nil {
+		s.mu.Lock()
+		s.states[state.Node] = state.Status
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// testNode 创建测试节点，fail 为 true 时节点失败
+func testNode(name string, fail bool, deps ...string) Node {
+	params := name + ":ok"
+	if fail {
+		params = name + ":fail"
+	}
+	raw, _ := json.Marshal(params)
+	return Node{Name: name, Method: testMethod, Params: raw, DependsOn: deps}
+}
+
+func TestBuildGraphErrors(t *testing.T) {
+	cfg := config.DAGExecutorConfig{MaxNodes: 3}
+	tests := []struct {
+		name    string
+		nodes   []Node
+		wantErr string
+	}{
+		{name: "empty", wantErr: "no nodes"},
+		{
+			name:    "too many",
+			nodes:   []Node{testNode("a", false), testNode("b", false), testNode("c", false), testNode("d", false)},
+			wantErr: "too many nodes",
+		},
+		{name: "no name", nodes: []Node{testNode("", false)}, wantErr: "name is required"},
+		{name: "duplicate", nodes: []Node{testNode("a", false), testNode("a", false)}, wantErr: `duplicate node name "a"`},
+		{name: "unknown method", nodes: []Node{{Name: "a", Method: "NOPE"}}, wantErr: `unknown method "NOPE"`},
+		{name: "nested", nodes: []Node{{Name: "a", Method: pb.Method_DAG.String()}}, wantErr: "not allowed here"},
+		{name: "unknown dependency", nodes: []Node{testNode("a", false, "x")}, wantErr: `unknown node "x"`},
+		{name: "self dependency", nodes: []Node{testNode("a", false, "a")}, wantErr: "depends on itself"},
+		{
+			name:    "cycle",
+			nodes:   []Node{testNode("a", false, "c"), testNode("b", false, "a"), testNode("c", false, "b")},
+			wantErr: "dependency cycle: a -> b -> c -> a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildGraph(cfg, tt.nodes)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("buildGraph() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	const (
+		succeeded = pb.NodeStatus_NODE_SUCCEEDED
+		failed    = pb.NodeStatus_NODE_FAILED
+		skipped   = pb.NodeStatus_NODE_SKIPPED
+	)
+	tests := []struct {
+		name  string
+		width int
+		nodes []Node
+		want  map[string]pb.NodeStatus
+	}{
+		{
+			name:  "chain",
+			width: 4,
+			nodes: []Node{testNode("a", false), testNode("b", false, "a"), testNode("c", false, "b")},
+			want:  map[string]pb.NodeStatus{"a": succeeded, "b": succeeded, "c": succeeded},
+		},
+		{
+			name:  "failure skips downstream",
+			width: 4,
+			nodes: []Node{testNode("a", true), testNode("b", false, "a"), testNode("c", false, "b")},
+			want:  map[string]pb.NodeStatus{"a": failed, "b": skipped, "c": skipped},
+		},
+		{
+			name:  "diamond with failed branch",
+			width: 4,
+			nodes: []Node{
+				testNode("a", false),
+				testNode("b", true, "a"),
+				testNode("c", false, "a"),
+				testNode("d", false, "b", "c"),
+			},
+			want: map[string]pb.NodeStatus{"a": succeeded, "b": failed, "c": succeeded, "d": skipped},
+		},
+		{
+			name:  "unrelated branch continues",
+			width: 1,
+			nodes: []Node{
+				testNode("a", true),
+				testNode("b", false, "a"),
+				testNode("x", false),
+				testNode("y", false, "x"),
+			},
+			want: map[string]pb.NodeStatus{"a": failed, "b": skipped, "x": succeeded, "y": succeeded},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := buildGraph(config.DAGExecutorConfig{MaxNodes: 10}, tt.nodes)
+			if err != nil {
+				t.Fatalf("buildGraph() error: %v", err)
+			}
+			executedMu.Lock()
+			executed = nil
+			executedMu.Unlock()
+
+			sink := &recordSink{states: make(map[string]pb.NodeStatus)}
+			r := &run{
+				sink:      sink,
+				nodes:     nodes,
+				remaining: make([]int, len(nodes)),
+				blocked:   make([]bool, len(nodes)),
+				result:    &pb.TaskResult{},
+				cancel:    func() {},
+			}
+			r.schedule(context.Background(), tt.width)
+
+			for name, want := range tt.want {
+				if got := sink.states[name]; got != want {
+					t.Errorf("node %s: status %v, want %v", name, got, want)
+				}
+			}
+			// 节点只在依赖全部执行后执行，跳过的节点不执行
+			for _, def := range tt.nodes {
+				pos := slices.Index(executed, def.Name)
+				if tt.want[def.Name] == skipped {
+					if pos >= 0 {
+						t.Errorf("skipped node %s was executed", def.Name)
+					}
+					continue
+				}
+				for _, dep := range def.DependsOn {
+					if depPos := slices.Index(executed, dep); depPos < 0 || depPos > pos {
+						t.Errorf("node %s executed before its dependency %s: %v", def.Name, dep, executed)
+					}
+				}
+			}
+		})
+	}
+}
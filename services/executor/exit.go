@@ -0,0 +1,49 @@
+package executor
+
+import (
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// exitStatusReason 退出码详情的 ErrorInfo.Reason
+	exitStatusReason = "EXIT_STATUS"
+	// exitStatusDomain 退出码详情的 ErrorInfo.Domain
+	exitStatusDomain = "goumang"
+	// exitCodeKey 退出码在 ErrorInfo.Metadata 中的键
+	exitCodeKey = "exitCode"
+)
+
+// ExitStatusError 构造携带退出码的错误，退出码放在 ErrorInfo 详情中供调用方读取
+func ExitStatusError(code int, msg string) error {
+	st := status.New(codes.Internal, msg)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   exitStatusReason,
+		Domain:   exitStatusDomain,
+		Metadata: map[string]string{exitCodeKey: strconv.Itoa(code)},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// ExitCode 读取执行错误中的退出码：nil 返回 0，不携带退出码的错误返回 -1
+func ExitCode(err error) int32 {
+	if err == nil {
+		return 0
+	}
+	for _, detail := range status.Convert(err).Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.Reason != exitStatusReason || info.Domain != exitStatusDomain {
+			continue
+		}
+		if code, errA := strconv.Atoi(info.Metadata[exitCodeKey]); errA == nil {
+			return int32(code)
+		}
+	}
+	return -1
+}
@@ -3,71 +3,154 @@ package executor
 import (
 	"fmt"
 	"goumang-worker/services/pb"
+	"sort"
 	"sync"
 )
 
 // executorFactory 执行器工厂实现
 type executorFactory struct {
-	creators map[pb.Method]Creator
-	mu       sync.RWMutex
+	// 按方法名注册，内置方法使用 pb.Method 枚举名
+	creators map[string]Creator
+	params   map[string]*methodParams
+	// 中间件对之后创建的执行器生效
+	middlewares []Middleware
+	mu          sync.RWMutex
 }
 
 // NewFactory 创建新的执行器工厂
 func NewFactory() Factory {
 	return &executorFactory{
-		creators: make(map[pb.Method]Creator),
+		creators: make(map[string]Creator),
+		params:   make(map[string]*methodParams),
 	}
 }
 
-// CreateExecutor 创建指定类型的执行器
-func (f *executorFactory) CreateExecutor(method pb.Method) (Executor, error) {
+// CreateExecutor 创建指定类型的执行器，声明了参数类型的方法在执行前由工厂解码参数
+func (f *executorFactory) CreateExecutor(name string) (Executor, error) {
 	f.mu.RLock()
-	creator, exists := f.creators[method]
+	creator, exists := f.creators[name]
+	mp := f.params[name]
+	middlewares := f.middlewares
 	f.mu.RUnlock()
 
 	if !exists {
-		return nil, fmt.Errorf("unsupported executor method: %v", method)
+		return nil, fmt.Errorf("unsupported executor method: %s", name)
 	}
 
-	return creator(), nil
+	exec := creator()
+	if mp != nil && mp.decodes() {
+		exec = &paramsExecutor{exec: exec, params: mp}
+	}
+	if len(middlewares) == 0 {
+		return exec, nil
+	}
+	return newChainedExecutor(name, exec, middlewares), nil
+}
+
+// Use 追加中间件，先追加的中间件在外层
+func (f *executorFactory) Use(middlewares ...Middleware) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// 复制后追加，已创建的执行器持有的切片不受影响
+	f.middlewares = append(append([]Middleware{}, f.middlewares...), middlewares...)
 }
 
-// SupportedMethods 返回支持的执行方法列表
-func (f *executorFactory) SupportedMethods() []pb.Method {
+// SupportedMethods 返回支持的方法名及参数版本，按方法名排序
+func (f *executorFactory) SupportedMethods() []SupportedMethod {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
-	methods := make([]pb.Method, 0, len(f.creators))
-	for method := range f.creators {
+	methods := make([]SupportedMethod, 0, len(f.creators))
+	for name := range f.creators {
+		method := SupportedMethod{Name: name}
+		if mp, exists := f.params[name]; exists {
+			method.Version = mp.spec.Version
+		}
 		methods = append(methods, method)
 	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
 	return methods
 }
 
 // RegisterExecutor 注册执行器创建函数
-func (f *executorFactory) RegisterExecutor(method pb.Method, creator Creator) {
+func (f *executorFactory) RegisterExecutor(name string, creator Creator) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, exists := f.creators[name]; exists {
+		panic(fmt.Errorf("executor method %s is already registered", name))
+	}
+
+	f.creators[name] = creator
+}
+
+// UnregisterExecutor 移除执行器创建函数与参数声明
+func (f *executorFactory) UnregisterExecutor(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.creators, name)
+	delete(f.params, name)
+}
+
+// RegisterParams 注册方法参数声明
+func (f *executorFactory) RegisterParams(name string, spec ParamsSpec) {
+	params := newMethodParams(spec)
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	if _, exists := f.creators[method]; exists {
-		panic(fmt.Errorf("executor method %v is already registered", method))
+	if _, exists := f.params[name]; exists {
+		panic(fmt.Errorf("params of method %s is already registered", name))
 	}
 
-	f.creators[method] = creator
+	f.params[name] = params
 }
 
-func (f *executorFactory) IsSupported(method pb.Method) bool {
+// ValidateParams 校验方法参数
+func (f *executorFactory) ValidateParams(name string, version uint32, params string) error {
+	f.mu.RLock()
+	mp, exists := f.params[name]
+	f.mu.RUnlock()
+
+	if !exists {
+		return nil
+	}
+	return mp.validate(version, params)
+}
+
+// DescribeMethods 返回支持的方法及其参数说明
+func (f *executorFactory) DescribeMethods() []MethodDescription {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	list := make([]MethodDescription, 0, len(f.creators))
+	for name := range f.creators {
+		desc := MethodDescription{Name: name}
+		if mp, exists := f.params[name]; exists {
+			desc.Version = mp.spec.Version
+			desc.Description = mp.spec.Description
+			desc.Schema = mp.schema
+		}
+		list = append(list, desc)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+func (f *executorFactory) IsSupported(name string) bool {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
-	_, exists := f.creators[method]
+	_, exists := f.creators[name]
 	return exists
 }
 
 // GetCreator 获取指定方法的创建函数（用于测试）
-func (f *executorFactory) GetCreator(method pb.Method) (Creator, bool) {
+func (f *executorFactory) GetCreator(name string) (Creator, bool) {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
-	creator, exists := f.creators[method]
+	creator, exists := f.creators[name]
 	return creator, exists
 }
 
@@ -85,24 +168,71 @@ func DefaultFactory() Factory {
 }
 
 // CreateExecutor 使用默认工厂创建执行器
-func CreateExecutor(method pb.Method) (Executor, error) {
-	return defaultFactory.CreateExecutor(method)
+func CreateExecutor(name string) (Executor, error) {
+	return defaultFactory.CreateExecutor(name)
 }
 
 // SupportedMethods 获取默认工厂支持的方法
-func SupportedMethods() []pb.Method {
+func SupportedMethods() []SupportedMethod {
 	return defaultFactory.SupportedMethods()
 }
 
+// Use 在默认工厂中追加中间件
+func Use(middlewares ...Middleware) {
+	defaultFactory.Use(middlewares...)
+}
+
 // RegisterExecutor 在默认工厂中注册执行器
-func RegisterExecutor(method pb.Method, creator Creator) {
-	defaultFactory.RegisterExecutor(method, creator)
+func RegisterExecutor(name string, creator Creator) {
+	defaultFactory.RegisterExecutor(name, creator)
+}
+
+// RegisterMethod 按 pb.Method 枚举在默认工厂中注册执行器，兼容按枚举注册的旧调用方式
+func RegisterMethod(method pb.Method, creator Creator) {
+	defaultFactory.RegisterExecutor(method.String(), creator)
+}
+
+// UnregisterExecutor 在默认工厂中移除执行器
+func UnregisterExecutor(name string) {
+	defaultFactory.UnregisterExecutor(name)
 }
 
 // IsSupported 检查默认工厂是否支持指定方法
-func IsSupported(method pb.Method) bool {
+func IsSupported(name string) bool {
 	if factory, ok := defaultFactory.(*executorFactory); ok {
-		return factory.IsSupported(method)
+		return factory.IsSupported(name)
 	}
 	return false
 }
+
+// RegisterParams 在默认工厂中注册方法参数声明
+func RegisterParams(name string, spec ParamsSpec) {
+	defaultFactory.RegisterParams(name, spec)
+}
+
+// ValidateParams 使用默认工厂校验方法参数
+func ValidateParams(name string, version uint32, params string) error {
+	return defaultFactory.ValidateParams(name, version, params)
+}
+
+// DescribeMethods 返回默认工厂支持的方法及其参数说明
+func DescribeMethods() []MethodDescription {
+	return defaultFactory.DescribeMethods()
+}
+
+// MethodEnum 返回方法名对应的枚举值，不在枚举中的方法（如插件方法）返回 pb.Method_OTHER
+func MethodEnum(name string) pb.Method {
+	value, ok := pb.Method_value[name]
+	if !ok || pb.Method(value) == pb.Method_OTHER {
+		return pb.Method_OTHER
+	}
+	return pb.Method(value)
+}
+
+// RequestMethod 返回请求的方法名，method_name 为空时使用 method 枚举名
+func RequestMethod(req *pb.TaskRequest) string {
+	if req.MethodName != "" {
+		return req.MethodName
+	}
+	return req.Method.String()
+}
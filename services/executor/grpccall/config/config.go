@@ -0,0 +1,70 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// Target 允许调用的 gRPC 目标
+type Target struct {
+	// 目标名称，请求通过名称引用
+	Name string `yaml:"name"`
+	// 目标地址
+	Address string `yaml:"address"`
+	// 是否使用 TLS
+	TLS bool `yaml:"tls"`
+	// FileDescriptorSet 文件路径，为空时使用服务端反射
+	DescriptorSet string `yaml:"descriptorSet"`
+}
+
+// GRPCCallExecutorConfig gRPC 调用执行器配置
+type GRPCCallExecutorConfig struct {
+	Targets []Target `yaml:"targets"`
+	// 单次调用最多转发的响应消息数，0 表示不限制
+	MaxResponses int `yaml:"maxResponses"`
+	// 单条响应消息最大字节数
+	MaxRecvMsgSize int `yaml:"maxRecvMsgSize"`
+}
+
+// Config gRPC 调用配置结构
+type Config struct {
+	GRPCCall GRPCCallExecutorConfig `yaml:"grpcCall"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "grpccall.yaml"), &globalConfig); err != nil {
+			panic("loadConfig grpccall.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if globalConfig.GRPCCall.MaxRecvMsgSize <= 0 {
+			globalConfig.GRPCCall.MaxRecvMsgSize = 4 << 20
+		}
+	})
+}
+
+// GetGRPCCallConfig 获取 gRPC 调用执行器配置
+func GetGRPCCallConfig() GRPCCallExecutorConfig {
+	lazyLoadConfig()
+	return globalConfig.GRPCCall
+}
+
+// GetTarget 按名称查找目标
+func GetTarget(name string) (Target, bool) {
+	for _, target := range GetGRPCCallConfig().Targets {
+		if target.Name == name {
+			return target, true
+		}
+	}
+	return Target{}, false
+}
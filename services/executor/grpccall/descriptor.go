@@ -0,0 +1,121 @@
+package grpccall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// loadDescriptorSet 从 FileDescriptorSet 文件构建描述符集合
+func loadDescriptorSet(filePath string) (*descriptorpb.FileDescriptorSet, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("parse descriptor set failed: %w", err)
+	}
+	return set, nil
+}
+
+// resolveByReflection 通过服务端反射获取服务所在文件及其全部依赖
+func resolveByReflection(ctx context.Context, conn *grpc.ClientConn, service string) (*descriptorpb.FileDescriptorSet, error) {
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("open reflection stream failed: %w", err)
+	}
+	defer func() {
+		_ = stream.CloseSend()
+	}()
+
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+	var ordered []*descriptorpb.FileDescriptorProto
+
+	// 先按服务名获取文件，再按文件名补齐缺失的依赖
+	pending := []*reflectionpb.ServerReflectionRequest{{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}}
+	for len(pending) > 0 {
+		req := pending[0]
+		pending = pending[1:]
+		if err = stream.Send(req); err != nil {
+			return nil, fmt.Errorf("reflection request failed: %w", err)
+		}
+		resp, errR := stream.Recv()
+		if errR != nil {
+			return nil, fmt.Errorf("reflection response failed: %w", errR)
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return nil, fmt.Errorf("reflection error: %s", errResp.GetErrorMessage())
+		}
+		fdResp := resp.GetFileDescriptorResponse()
+		if fdResp == nil {
+			return nil, errors.New("unexpected reflection response")
+		}
+
+		for _, raw := range fdResp.GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err = proto.Unmarshal(raw, fd); err != nil {
+				return nil, fmt.Errorf("parse file descriptor failed: %w", err)
+			}
+			if _, exists := files[fd.GetName()]; exists {
+				continue
+			}
+			files[fd.GetName()] = fd
+			ordered = append(ordered, fd)
+		}
+
+		for _, fd := range ordered {
+			for _, dep := range fd.GetDependency() {
+				if _, exists := files[dep]; exists || isPending(pending, dep) {
+					continue
+				}
+				pending = append(pending, &reflectionpb.ServerReflectionRequest{
+					MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+				})
+			}
+		}
+	}
+
+	return &descriptorpb.FileDescriptorSet{File: ordered}, nil
+}
+
+// isPending 判断依赖文件是否已在待请求列表中
+func isPending(pending []*reflectionpb.ServerReflectionRequest, filename string) bool {
+	for _, req := range pending {
+		if req.GetFileByFilename() == filename {
+			return true
+		}
+	}
+	return false
+}
+
+// findMethod 在描述符集合中查找方法，fullMethod 形如 "pkg.Service/Method" 或 "pkg.Service.Method"
+func findMethod(set *descriptorpb.FileDescriptorSet, service, method string) (protoreflect.MethodDescriptor, error) {
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("build descriptors failed: %w", err)
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("service %q not found: %w", service, err)
+	}
+	serviceDesc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a service", service)
+	}
+	methodDesc := serviceDesc.Methods().ByName(protoreflect.Name(method))
+	if methodDesc == nil {
+		return nil, fmt.Errorf("method %q not found in service %q", method, service)
+	}
+	return methodDesc, nil
+}
@@ -0,0 +1,186 @@
+package grpccall
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/grpccall/config"
+	"goumang-worker/services/pb"
+	"io"
+	"strings"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// init 自动注册 gRPC 调用执行器到默认工厂
+func init() {
+	executor.RegisterExecutor(pb.Method_GRPC_CALL.String(), NewExecutor)
+	executor.RegisterParams(pb.Method_GRPC_CALL.String(), executor.ParamsSpec{
+		Version:     1,
+		Description: "gRPC call to a configured target resolved by reflection",
+		Params:      Params{},
+	})
+}
+
+// Params gRPC 调用参数（method_params 的 JSON 内容）
+type Params struct {
+	// 配置中的目标名称
+	Target string `json:"target" schema:"required,minLength=1"`
+	// 完整方法名，形如 "pkg.Service/Method"
+	Method string `json:"method" schema:"required,minLength=1"`
+	// JSON 格式的请求体
+	Request json.RawMessage `json:"request"`
+	// 请求元数据
+	Metadata map[string]string `json:"metadata"`
+}
+
+// Executor gRPC 调用执行器
+type Executor struct{}
+
+// NewExecutor 创建新的 gRPC 调用执行器
+func NewExecutor() executor.Executor {
+	return &Executor{}
+}
+
+// Execute 调用目标服务，响应消息以 JSON 行返回，支持服务端流
+func (e *Executor) Execute(ctx context.Context, params string, sink executor.Sink) error {
+	p, err := executor.DecodeParams[Params](ctx, params)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid params: %v", err))
+	}
+	service, method, ok := splitMethod(p.Method)
+	if !ok {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid method name: %q", p.Method))
+	}
+
+	target, ok := config.GetTarget(p.Target)
+	if !ok {
+		logit.Context(ctx).WarnW("logType", "grpc call denied", "reason", "unknown target", "target", p.Target)
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("target not allowed: %q", p.Target))
+	}
+	cfg := config.GetGRPCCallConfig()
+
+	creds := insecure.NewCredentials()
+	if target.TLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.NewClient(target.Address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(cfg.MaxRecvMsgSize)),
+	)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("dial target failed: %v", err))
+	}
+	defer func() {
+		if errC := conn.Close(); errC != nil {
+			logit.Context(ctx).WarnW("conn.Close.Err", errC)
+		}
+	}()
+
+	// 解析方法描述符
+	var set *descriptorpb.FileDescriptorSet
+	if target.DescriptorSet != "" {
+		set, err = loadDescriptorSet(target.DescriptorSet)
+	} else {
+		set, err = resolveByReflection(ctx, conn, service)
+	}
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("resolve descriptors failed: %v", err))
+	}
+	methodDesc, err := findMethod(set, service, method)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if methodDesc.IsStreamingClient() {
+		return status.Error(codes.InvalidArgument, "client streaming methods are not supported")
+	}
+
+	req := dynamicpb.NewMessage(methodDesc.Input())
+	if len(p.Request) > 0 {
+		if err = protojson.Unmarshal(p.Request, req); err != nil {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid request body: %v", err))
+		}
+	}
+
+	callCtx := ctx
+	if len(p.Metadata) > 0 {
+		callCtx = metadata.NewOutgoingContext(ctx, metadata.New(p.Metadata))
+	}
+	fullMethod := "/" + service + "/" + method
+	clientStream, err := conn.NewStream(callCtx, &grpc.StreamDesc{ServerStreams: methodDesc.IsStreamingServer()}, fullMethod)
+	if err != nil {
+		return e.callError(ctx, err)
+	}
+	if err = clientStream.SendMsg(req); err != nil {
+		return e.callError(ctx, err)
+	}
+	if err = clientStream.CloseSend(); err != nil {
+		return e.callError(ctx, err)
+	}
+
+	result := &pb.TaskResult{}
+	marshaler := protojson.MarshalOptions{}
+	for {
+		resp := dynamicpb.NewMessage(methodDesc.Output())
+		if err = clientStream.RecvMsg(resp); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return e.callError(ctx, err)
+		}
+
+		result.TotalLines++
+		if cfg.MaxResponses > 0 && result.ForwardedLines >= uint64(cfg.MaxResponses) {
+			result.Truncated = true
+			continue
+		}
+		line, errM := marshaler.Marshal(resp)
+		if errM != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("encode response failed: %v", errM))
+		}
+		if errS := sink.Stdout(string(line)); errS != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to send output: %v", errS))
+		}
+		result.ForwardedLines++
+		result.TotalBytes += uint64(len(line)) + 1
+		result.ForwardedBytes += uint64(len(line)) + 1
+	}
+
+	if errS := sink.Result(result); errS != nil {
+		logit.Context(ctx).WarnW("result.stream.Send.Err", errS)
+	}
+	return nil
+}
+
+// callError 转换远端调用错误，远端状态码附在错误信息中
+func (e *Executor) callError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("call canceled or timeout: %v", ctx.Err()))
+	}
+	logit.Context(ctx).WarnW("grpc.call.Err", err)
+	st := status.Convert(err)
+	return status.Error(codes.Internal, fmt.Sprintf("grpc call failed: %s: %s", st.Code(), st.Message()))
+}
+
+// splitMethod 拆分完整方法名为服务名与方法名
+func splitMethod(fullMethod string) (service, method string, ok bool) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if idx := strings.LastIndex(fullMethod, "/"); idx > 0 {
+		service, method = fullMethod[:idx], fullMethod[idx+1:]
+	} else if idx = strings.LastIndex(fullMethod, "."); idx > 0 {
+		service, method = fullMethod[:idx], fullMethod[idx+1:]
+	}
+	return service, method, service != "" && method != ""
+}
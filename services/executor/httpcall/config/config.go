@@ -0,0 +1,66 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// HTTPExecutorConfig HTTP 执行器配置
+type HTTPExecutorConfig struct {
+	// 允许访问的目标主机，支持 "*.example.com" 通配与 "host:port" 精确端口
+	AllowedHosts []string `yaml:"allowedHosts"`
+	// 允许的协议
+	AllowedSchemes []string `yaml:"allowedSchemes"`
+	// 单次请求超时上限秒数
+	MaxTimeoutSec int `yaml:"maxTimeoutSec"`
+	// 重试次数上限
+	MaxRetry int `yaml:"maxRetry"`
+	// 请求体最大字节数
+	MaxRequestBytes int `yaml:"maxRequestBytes"`
+	// 响应体最大转发字节数，超出部分丢弃
+	MaxResponseBytes int64 `yaml:"maxResponseBytes"`
+	// 是否跟随重定向（每一跳同样校验主机白名单）
+	FollowRedirects bool `yaml:"followRedirects"`
+}
+
+// Config HTTP 配置结构
+type Config struct {
+	HTTP HTTPExecutorConfig `yaml:"http"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "http.yaml"), &globalConfig); err != nil {
+			panic("loadConfig http.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if len(globalConfig.HTTP.AllowedSchemes) == 0 {
+			globalConfig.HTTP.AllowedSchemes = []string{"http", "https"}
+		}
+		if globalConfig.HTTP.MaxTimeoutSec <= 0 {
+			globalConfig.HTTP.MaxTimeoutSec = 60
+		}
+		if globalConfig.HTTP.MaxRequestBytes <= 0 {
+			globalConfig.HTTP.MaxRequestBytes = 1 << 20
+		}
+		if globalConfig.HTTP.MaxResponseBytes <= 0 {
+			globalConfig.HTTP.MaxResponseBytes = 10 << 20
+		}
+	})
+}
+
+// GetHTTPConfig 获取 HTTP 执行器配置
+func GetHTTPConfig() HTTPExecutorConfig {
+	lazyLoadConfig()
+	return globalConfig.HTTP
+}
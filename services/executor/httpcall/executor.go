@@ -0,0 +1,239 @@
+package httpcall
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/httpcall/config"
+	"goumang-worker/services/pb"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// init 自动注册 HTTP 执行器到默认工厂
+func init() {
+	executor.RegisterExecutor(pb.Method_HTTP.String(), NewExecutor)
+	executor.RegisterParams(pb.Method_HTTP.String(), executor.ParamsSpec{
+		Version:     1,
+		Description: "HTTP request with status and body assertions",
+		Params:      Params{},
+	})
+}
+
+const (
+	// chunkSize 响应体分片大小
+	chunkSize = 32 * 1024
+	// sniffBytes 判断响应体是否为文本时检查的字节数
+	sniffBytes = 512
+	// maxRedirects 最大重定向次数
+	maxRedirects = 10
+)
+
+// errSend 向流发送数据失败
+var errSend = errors.New("failed to send output")
+
+// Executor HTTP 请求执行器
+type Executor struct{}
+
+// NewExecutor 创建新的 HTTP 执行器
+func NewExecutor() executor.Executor {
+	return &Executor{}
+}
+
+// Execute 执行 HTTP 请求
+func (e *Executor) Execute(ctx context.Context, params string, sink executor.Sink) error {
+	cfg := config.GetHTTPConfig()
+
+	p, err := parseParams(ctx, params, cfg)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = checkTarget(p.target, cfg); err != nil {
+		logit.Context(ctx).WarnW("logType", "http request denied", "reason", err.Error())
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("request not allowed: %v", err))
+	}
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if !cfg.FollowRedirects {
+				return http.ErrUseLastResponse
+			}
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			// 每一跳都需要校验白名单，防止通过重定向访问内部地址
+			return checkTarget(req.URL, cfg)
+		},
+	}
+
+	result := &pb.TaskResult{}
+	for attempt := 1; ; attempt++ {
+		last := attempt > p.Retry
+		retry, errA := e.attempt(ctx, client, p, attempt, last, cfg, sink, result)
+		if errA == nil {
+			break
+		}
+		if errors.Is(errA, errSend) {
+			return status.Error(codes.Internal, errA.Error())
+		}
+		if last || !retry || ctx.Err() != nil {
+			err = status.Error(codes.Internal, fmt.Sprintf("http request failed: %v", errA))
+			break
+		}
+
+		logit.Context(ctx).WarnW("http.attempt.Err", errA, "attempt", attempt)
+		if errS := sink.Stderr(fmt.Sprintf("attempt %d failed: %v, retrying", attempt, errA)); errS != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("%v: %v", errSend, errS))
+		}
+
+		// 线性退避
+		select {
+		case <-ctx.Done():
+			return status.Error(codes.Internal, fmt.Sprintf("request canceled or timeout: %v", ctx.Err()))
+		case <-time.After(time.Duration(attempt) * time.Second):
+		}
+	}
+
+	if errS := sink.Result(result); errS != nil {
+		logit.Context(ctx).WarnW("result.stream.Send.Err", errS)
+	}
+	return err
+}
+
+// attempt 发起一次请求并转发响应，retry 表示失败后是否值得重试
+func (e *Executor) attempt(ctx context.Context, client *http.Client, p *Params, attempt int, last bool,
+	cfg config.HTTPExecutorConfig, sink executor.Sink, result *pb.TaskResult) (retry bool, err error) {
+	reqCtx, cancel := context.WithTimeout(ctx, time.Duration(p.TimeoutSec)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, p.Method, p.target.String(), strings.NewReader(p.Body))
+	if err != nil {
+		return false, err
+	}
+	for key, value := range p.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer func() {
+		if errC := resp.Body.Close(); errC != nil {
+			logit.Context(ctx).WarnW("resp.Body.Close.Err", errC)
+		}
+	}()
+
+	// 根据响应体开头判断是否为文本，非文本以 base64 转发
+	body := bufio.NewReaderSize(resp.Body, chunkSize)
+	head, _ := body.Peek(sniffBytes)
+	binary := !utf8.Valid(head[:runeBoundary(head)])
+
+	headers := make(map[string]string, len(resp.Header))
+	for key, values := range resp.Header {
+		headers[key] = strings.Join(values, ", ")
+	}
+	if errS := sink.Event(&pb.TaskEvent{
+		Event: &pb.TaskEvent_HttpResponse{HttpResponse: &pb.HttpResponse{
+			StatusCode: int32(resp.StatusCode),
+			Headers:    headers,
+			Attempt:    int32(attempt),
+			BodyBase64: binary,
+		}},
+	}); errS != nil {
+		return false, fmt.Errorf("%w: %v", errSend, errS)
+	}
+
+	// 服务端错误且仍可重试时不转发响应体
+	if resp.StatusCode >= http.StatusInternalServerError && !last {
+		return true, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	kept, err := e.forwardBody(body, binary, cfg, sink, result)
+	if err != nil {
+		return !errors.Is(err, errSend), err
+	}
+
+	if !p.statusExpected(resp.StatusCode) {
+		return false, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return false, p.checkBody(kept)
+}
+
+// forwardBody 分片转发响应体，返回用于断言的响应体内容（不超过转发上限），达到上限后不再读取
+// 文本按字符边界切分，跨分片的不完整字符留到下一片；非文本每片单独 base64 编码
+func (e *Executor) forwardBody(body io.Reader, binary bool, cfg config.HTTPExecutorConfig,
+	sink executor.Sink, result *pb.TaskResult) ([]byte, error) {
+	var kept, pending []byte
+	emit := func(chunk []byte, final bool) error {
+		line := ""
+		if binary {
+			line = base64.StdEncoding.EncodeToString(chunk)
+		} else {
+			data := append(pending, chunk...)
+			cut := len(data)
+			if !final {
+				cut = runeBoundary(data)
+			}
+			line = strings.ToValidUTF8(string(data[:cut]), "\uFFFD")
+			pending = append([]byte(nil), data[cut:]...)
+		}
+		if line == "" {
+			return nil
+		}
+		if errS := sink.Stdout(line); errS != nil {
+			return fmt.Errorf("%w: %v", errSend, errS)
+		}
+		return nil
+	}
+
+	buf := make([]byte, chunkSize)
+	for {
+		n, errR := body.Read(buf)
+		if n > 0 {
+			result.TotalBytes += uint64(n)
+			chunk := buf[:n]
+			if remain := cfg.MaxResponseBytes - int64(len(kept)); int64(len(chunk)) > remain {
+				chunk = chunk[:max(remain, 0)]
+				result.Truncated = true
+			}
+			kept = append(kept, chunk...)
+			result.ForwardedBytes += uint64(len(chunk))
+			if err := emit(chunk, false); err != nil {
+				return nil, err
+			}
+			if result.Truncated {
+				return kept, nil
+			}
+		}
+		if errR == io.EOF {
+			return kept, emit(nil, true)
+		}
+		if errR != nil {
+			return nil, errR
+		}
+	}
+}
+
+// runeBoundary 返回 b 中最后一个完整字符的结束位置，末尾不完整的多字节字符不计入
+func runeBoundary(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if utf8.FullRune(b[i:]) {
+				return len(b)
+			}
+			return i
+		}
+	}
+	return len(b)
+}
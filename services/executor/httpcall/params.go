@@ -0,0 +1,131 @@
+package httpcall
+
+import (
+	"context"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/httpcall/config"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Params HTTP 请求参数（method_params 的 JSON 内容）
+type Params struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url" schema:"required,minLength=1"`
+	Headers map[string]string `json:"headers"`
+	Body    string            `json:"body"`
+	// 单次请求超时秒数
+	TimeoutSec int `json:"timeoutSec"`
+	// 期望的状态码，为空时接受 2xx
+	ExpectedStatus []int `json:"expectedStatus"`
+	// 失败后的重试次数（网络错误或 5xx）
+	Retry int `json:"retry"`
+	// 响应体需包含的字符串
+	BodyContains string `json:"bodyContains"`
+	// 响应体需匹配的正则
+	BodyRegex string `json:"bodyRegex"`
+
+	target    *url.URL
+	bodyRegex *regexp.Regexp
+}
+
+// parseParams 解析并校验请求参数
+func parseParams(ctx context.Context, raw string, cfg config.HTTPExecutorConfig) (*Params, error) {
+	p, err := executor.DecodeParams[Params](ctx, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid params: %w", err)
+	}
+
+	if p.Method == "" {
+		p.Method = http.MethodGet
+	}
+	p.Method = strings.ToUpper(p.Method)
+
+	target, err := url.Parse(p.URL)
+	if err != nil || target.Host == "" {
+		return nil, fmt.Errorf("invalid url: %q", p.URL)
+	}
+	p.target = target
+
+	// 白名单只校验 URL 主机，不允许通过 Host 头转发到其他后端
+	for key := range p.Headers {
+		if strings.EqualFold(key, "Host") || strings.HasPrefix(key, ":") {
+			return nil, fmt.Errorf("header %q is not allowed", key)
+		}
+	}
+
+	if len(p.Body) > cfg.MaxRequestBytes {
+		return nil, fmt.Errorf("request body exceeds %d bytes", cfg.MaxRequestBytes)
+	}
+	if p.TimeoutSec <= 0 || p.TimeoutSec > cfg.MaxTimeoutSec {
+		p.TimeoutSec = cfg.MaxTimeoutSec
+	}
+	if p.Retry < 0 {
+		p.Retry = 0
+	}
+	if p.Retry > cfg.MaxRetry {
+		p.Retry = cfg.MaxRetry
+	}
+	if p.BodyRegex != "" {
+		if p.bodyRegex, err = regexp.Compile(p.BodyRegex); err != nil {
+			return nil, fmt.Errorf("invalid bodyRegex: %w", err)
+		}
+	}
+
+	return p, nil
+}
+
+// statusExpected 判断状态码是否符合预期
+func (p *Params) statusExpected(code int) bool {
+	if len(p.ExpectedStatus) == 0 {
+		return code >= 200 && code < 300
+	}
+	return slices.Contains(p.ExpectedStatus, code)
+}
+
+// checkBody 校验响应体断言
+func (p *Params) checkBody(body []byte) error {
+	if p.BodyContains != "" && !strings.Contains(string(body), p.BodyContains) {
+		return fmt.Errorf("response body does not contain %q", p.BodyContains)
+	}
+	if p.bodyRegex != nil && !p.bodyRegex.Match(body) {
+		return fmt.Errorf("response body does not match %q", p.BodyRegex)
+	}
+	return nil
+}
+
+// checkTarget 校验目标地址的协议与主机是否在白名单内
+func checkTarget(target *url.URL, cfg config.HTTPExecutorConfig) error {
+	if !slices.Contains(cfg.AllowedSchemes, strings.ToLower(target.Scheme)) {
+		return fmt.Errorf("scheme %q not allowed", target.Scheme)
+	}
+
+	host := strings.ToLower(target.Hostname())
+	port := target.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[strings.ToLower(target.Scheme)]
+	}
+
+	for _, allowed := range cfg.AllowedHosts {
+		allowedHost, allowedPort, err := net.SplitHostPort(allowed)
+		if err != nil {
+			allowedHost, allowedPort = allowed, ""
+		}
+		allowedHost = strings.ToLower(allowedHost)
+		if allowedPort != "" && allowedPort != port {
+			continue
+		}
+		if allowedHost == host {
+			return nil
+		}
+		if suffix, ok := strings.CutPrefix(allowedHost, "*."); ok && strings.HasSuffix(host, "."+suffix) {
+			return nil
+		}
+	}
+	return fmt.Errorf("host %q not allowed", target.Host)
+}
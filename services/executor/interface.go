@@ -1,14 +1,19 @@
 package executor
 
-import (
-	"context"
-	"goumang-worker/services/pb"
-)
+import "context"
 
 // Executor 任务执行器接口
 type Executor interface {
-	// Execute 执行任务
-	Execute(ctx context.Context, params string, stream pb.Task_RunServer) error
+	// Execute 执行任务，输出写入 sink
+	Execute(ctx context.Context, params string, sink Sink) error
+}
+
+// SupportedMethod 支持的方法
+type SupportedMethod struct {
+	// 方法名，内置方法为 pb.Method 枚举名
+	Name string
+	// 参数版本，未声明参数时为 0
+	Version uint32
 }
 
 // Creator 执行器创建函数类型
@@ -17,14 +22,29 @@ type Creator func() Executor
 // Factory 执行器工厂接口
 type Factory interface {
 	// CreateExecutor 创建指定类型的执行器
-	CreateExecutor(method pb.Method) (Executor, error)
+	CreateExecutor(name string) (Executor, error)
 
-	// SupportedMethods 返回支持的执行方法列表
-	SupportedMethods() []pb.Method
+	// SupportedMethods 返回支持的方法名及参数版本
+	SupportedMethods() []SupportedMethod
 
 	// RegisterExecutor 注册执行器创建函数
-	RegisterExecutor(method pb.Method, creator Creator)
+	RegisterExecutor(name string, creator Creator)
+
+	// UnregisterExecutor 移除执行器创建函数与参数声明，用于插件等运行期提供的方法
+	UnregisterExecutor(name string)
+
+	// Use 追加执行中间件，对所有方法生效，先追加的中间件在外层
+	Use(middlewares ...Middleware)
 
 	// IsSupported 查看执行器创建函数是否存在
-	IsSupported(method pb.Method) bool
+	IsSupported(name string) bool
+
+	// RegisterParams 注册方法参数声明，直接提供的 Schema 不合法时 panic，外部 Schema 需先调用 Schema.Check
+	RegisterParams(name string, spec ParamsSpec)
+
+	// ValidateParams 校验方法参数，version 为 0 时不校验版本，未声明参数的方法不校验
+	ValidateParams(name string, version uint32, params string) error
+
+	// DescribeMethods 返回支持的方法及其参数说明，按方法排序
+	DescribeMethods() []MethodDescription
 }
@@ -0,0 +1,85 @@
+package executor
+
+import (
+	"context"
+	"time"
+)
+
+// Call 一次执行调用，中间件可修改参数
+type Call struct {
+	// 方法名
+	Method string
+	Params string
+	// 嵌套深度：直接由服务端发起的调用为 0，流水线步骤与 DAG 节点为 1
+	Depth int
+}
+
+// Handler 执行处理函数
+type Handler func(ctx context.Context, call *Call, sink Sink) error
+
+// Middleware 执行中间件，对所有方法统一生效（审计、指标、并发限制、密钥注入、超时等）
+type Middleware func(next Handler) Handler
+
+// Hooks 执行生命周期钩子，未设置的钩子跳过
+type Hooks struct {
+	// PreExecute 执行前调用，可替换 ctx 或修改参数，返回错误时不执行
+	PreExecute func(ctx context.Context, call *Call) (context.Context, error)
+	// PostExecute 执行结束后调用，无论成功与否，PreExecute 失败时同样调用
+	PostExecute func(ctx context.Context, call *Call, err error, elapsed time.Duration)
+	// OnError 执行失败时调用，返回值替换原错误
+	OnError func(ctx context.Context, call *Call, err error) error
+}
+
+// HookMiddleware 将生命周期钩子包装为中间件
+func HookMiddleware(hooks Hooks) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, call *Call, sink Sink) (err error) {
+			start := time.Now()
+			if hooks.PostExecute != nil {
+				defer func() {
+					hooks.PostExecute(ctx, call, err, time.Since(start))
+				}()
+			}
+			if hooks.PreExecute != nil {
+				if ctx, err = hooks.PreExecute(ctx, call); err != nil {
+					return err
+				}
+			}
+			if err = next(ctx, call, sink); err != nil && hooks.OnError != nil {
+				err = hooks.OnError(ctx, call, err)
+			}
+			return err
+		}
+	}
+}
+
+// chainedExecutor 套用中间件的执行器
+type chainedExecutor struct {
+	method  string
+	handler Handler
+}
+
+// newChainedExecutor 按顺序套用中间件，第一个中间件在最外层
+func newChainedExecutor(method string, exec Executor, middlewares []Middleware) Executor {
+	handler := func(ctx context.Context, call *Call, sink Sink) error {
+		return exec.Execute(ctx, call.Params, sink)
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return &chainedExecutor{method: method, handler: handler}
+}
+
+// Execute 实现 Executor，执行期间创建的子调用深度加一
+func (e *chainedExecutor) Execute(ctx context.Context, params string, sink Sink) error {
+	depth := callDepth(ctx)
+	return e.handler(context.WithValue(ctx, callDepthKey{}, depth+1), &Call{Method: e.method, Params: params, Depth: depth}, sink)
+}
+
+type callDepthKey struct{}
+
+// callDepth 读取上下文中的调用深度
+func callDepth(ctx context.Context) int {
+	depth, _ := ctx.Value(callDepthKey{}).(int)
+	return depth
+}
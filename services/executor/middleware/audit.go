@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/middleware/config"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc/status"
+)
+
+// newAudit 审计中间件：记录每次调用的方法、参数摘要、耗时与结果，参数本身不落日志
+func newAudit(_ config.Entry) (executor.Middleware, error) {
+	return executor.HookMiddleware(executor.Hooks{
+		PreExecute: func(ctx context.Context, call *executor.Call) (context.Context, error) {
+			sum := sha256.Sum256([]byte(call.Params))
+			logit.Context(ctx).InfoW(
+				"logType", "task audit start",
+				"runTaskId", executor.TaskInfoFromContext(ctx).RunTaskID,
+				"method", call.Method,
+				"depth", call.Depth,
+				"paramsSha256", hex.EncodeToString(sum[:]),
+				"paramsBytes", len(call.Params),
+			)
+			return ctx, nil
+		},
+		PostExecute: func(ctx context.Context, call *executor.Call, err error, elapsed time.Duration) {
+			st := status.Convert(err)
+			logit.Context(ctx).InfoW(
+				"logType", "task audit end",
+				"runTaskId", executor.TaskInfoFromContext(ctx).RunTaskID,
+				"method", call.Method,
+				"depth", call.Depth,
+				"code", st.Code().String(),
+				"error", st.Message(),
+				"exitCode", executor.ExitCode(err),
+				"durationMs", elapsed.Milliseconds(),
+			)
+		},
+	}), nil
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/middleware/config"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// semaphore 计数信号量，nil 表示不限制
+type semaphore chan struct{}
+
+// acquire 获取名额，ctx 结束时放弃等待
+func (s semaphore) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release 归还名额
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
+
+// newSemaphore 创建信号量，limit 不大于 0 时不限制
+func newSemaphore(limit int) semaphore {
+	if limit <= 0 {
+		return nil
+	}
+	return make(semaphore, limit)
+}
+
+// newConcurrency 并发限制中间件：超出限制的任务排队等待，直到有空闲名额或任务超时
+// 只限制服务端直接发起的调用，流水线步骤与 DAG 节点占用所属任务的名额，避免互相等待
+func newConcurrency(entry config.Entry) (executor.Middleware, error) {
+	global := newSemaphore(entry.MaxConcurrent)
+	perMethod := make(map[string]semaphore, len(entry.Methods))
+	for _, m := range entry.Methods {
+		perMethod[m.Name] = newSemaphore(m.MaxConcurrent)
+	}
+
+	return func(next executor.Handler) executor.Handler {
+		return func(ctx context.Context, call *executor.Call, sink executor.Sink) error {
+			if call.Depth > 0 {
+				return next(ctx, call, sink)
+			}
+
+			// 先占方法名额再占全局名额，避免占着全局名额等待方法名额
+			sems := []semaphore{perMethod[call.Method], global}
+			for i, sem := range sems {
+				if err := sem.acquire(ctx); err != nil {
+					for _, held := range sems[:i] {
+						held.release()
+					}
+					return status.Error(codes.ResourceExhausted, fmt.Sprintf("waiting for concurrency slot: %v", err))
+				}
+			}
+			defer func() {
+				for _, sem := range sems {
+					sem.release()
+				}
+			}()
+			return next(ctx, call, sink)
+		}
+	}, nil
+}
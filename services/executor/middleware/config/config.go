@@ -0,0 +1,76 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// MethodEntry 按方法覆盖的限制
+type MethodEntry struct {
+	// 方法名，如 "SHELL"
+	Name string `yaml:"name"`
+	// 超时秒数（timeout）
+	TimeoutSec int `yaml:"timeoutSec"`
+	// 最大并发数（concurrency）
+	MaxConcurrent int `yaml:"maxConcurrent"`
+}
+
+// Secret 可注入到参数中的密钥
+type Secret struct {
+	// 参数中引用的名称，写作 ${secret:<name>}
+	Name string `yaml:"name"`
+	// 从 worker 环境变量读取
+	Env string `yaml:"env"`
+	// 从文件读取，去掉末尾换行，优先于 env
+	File string `yaml:"file"`
+	// 允许引用该密钥的方法名，必填
+	Methods []string `yaml:"methods"`
+}
+
+// Entry 中间件配置项，各中间件只读取自己用到的字段
+type Entry struct {
+	// 中间件名称
+	Name string `yaml:"name"`
+	// 默认超时秒数（timeout），0 表示不限制
+	TimeoutSec int `yaml:"timeoutSec"`
+	// 全局最大并发数（concurrency），0 表示不限制
+	MaxConcurrent int `yaml:"maxConcurrent"`
+	// 按方法覆盖（timeout、concurrency）
+	Methods []MethodEntry `yaml:"methods"`
+	// 密钥列表（secrets）
+	Secrets []Secret `yaml:"secrets"`
+}
+
+// MiddlewareConfig 中间件配置
+type MiddlewareConfig struct {
+	// 按顺序套用，先配置的在最外层
+	Chain []Entry `yaml:"chain"`
+}
+
+// Config 中间件配置结构
+type Config struct {
+	Middleware MiddlewareConfig `yaml:"middleware"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "middleware.yaml"), &globalConfig); err != nil {
+			panic("loadConfig middleware.yaml err:" + err.Error())
+		}
+	})
+}
+
+// GetMiddlewareConfig 获取中间件配置
+func GetMiddlewareConfig() MiddlewareConfig {
+	lazyLoadConfig()
+	return globalConfig.Middleware
+}
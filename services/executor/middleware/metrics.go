@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/middleware/config"
+	"sync"
+	"time"
+)
+
+// Stats 单个方法的执行统计
+type Stats struct {
+	// 正在执行的调用数
+	Running int64
+	// 已完成的调用数
+	Total int64
+	// 失败的调用数
+	Failed int64
+	// 已完成调用的累计耗时（毫秒）
+	TotalDurationMs int64
+}
+
+var (
+	stats   = make(map[string]*Stats)
+	statsMu sync.Mutex
+)
+
+// Snapshot 返回各方法执行统计的副本，未启用 metrics 中间件时为空
+func Snapshot() map[string]Stats {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	snapshot := make(map[string]Stats, len(stats))
+	for method, s := range stats {
+		snapshot[method] = *s
+	}
+	return snapshot
+}
+
+// methodStats 返回方法的统计项，调用方需持有 statsMu
+func methodStats(method string) *Stats {
+	s, ok := stats[method]
+	if !ok {
+		s = &Stats{}
+		stats[method] = s
+	}
+	return s
+}
+
+// newMetrics 指标中间件：按方法统计调用数、失败数与耗时（流水线步骤与 DAG 节点按各自方法计入）
+func newMetrics(_ config.Entry) (executor.Middleware, error) {
+	return executor.HookMiddleware(executor.Hooks{
+		PreExecute: func(ctx context.Context, call *executor.Call) (context.Context, error) {
+			statsMu.Lock()
+			methodStats(call.Method).Running++
+			statsMu.Unlock()
+			return ctx, nil
+		},
+		PostExecute: func(_ context.Context, call *executor.Call, err error, elapsed time.Duration) {
+			statsMu.Lock()
+			defer statsMu.Unlock()
+			s := methodStats(call.Method)
+			s.Running--
+			s.Total++
+			s.TotalDurationMs += elapsed.Milliseconds()
+			if err != nil {
+				s.Failed++
+			}
+		},
+	}), nil
+}
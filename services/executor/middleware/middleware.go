@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/middleware/config"
+	"sync"
+)
+
+// Builder 根据配置项创建中间件
+type Builder func(entry config.Entry) (executor.Middleware, error)
+
+var (
+	builders   = make(map[string]Builder)
+	buildersMu sync.RWMutex
+	setupOnce  sync.Once
+)
+
+// init 注册内置中间件
+func init() {
+	Register("audit", newAudit)
+	Register("metrics", newMetrics)
+	Register("concurrency", newConcurrency)
+	Register("secrets", newSecrets)
+	Register("timeout", newTimeout)
+}
+
+// Register 注册中间件，名称用于 middleware.yaml 的 chain 配置
+func Register(name string, builder Builder) {
+	buildersMu.Lock()
+	defer buildersMu.Unlock()
+
+	if _, exists := builders[name]; exists {
+		panic(fmt.Errorf("middleware %q is already registered", name))
+	}
+	builders[name] = builder
+}
+
+// Setup 按 middleware.yaml 的顺序创建中间件并挂到默认工厂，重复调用只生效一次
+func Setup() {
+	setupOnce.Do(func() {
+		entries := config.GetMiddlewareConfig().Chain
+		chain := make([]executor.Middleware, 0, len(entries))
+		for _, entry := range entries {
+			buildersMu.RLock()
+			builder, exists := builders[entry.Name]
+			buildersMu.RUnlock()
+			if !exists {
+				panic(fmt.Errorf("middleware.yaml: unknown middleware %q", entry.Name))
+			}
+			mw, err := builder(entry)
+			if err != nil {
+				panic(fmt.Errorf("middleware.yaml: middleware %q: %v", entry.Name, err))
+			}
+			chain = append(chain, mw)
+		}
+		executor.Use(chain...)
+	})
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/middleware/config"
+	"goumang-worker/services/pb"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const maskedSecret = "******"
+
+// secretRef 参数中的密钥引用
+var secretRef = regexp.MustCompile(`\$\{secret:([A-Za-z0-9_.-]+)\}`)
+
+// newSecrets 密钥注入中间件：将参数中的 ${secret:<name>} 替换为密钥值，并在输出与错误信息中遮蔽密钥
+// 签名与参数校验针对替换前的参数，密钥不会出现在调度端
+func newSecrets(entry config.Entry) (executor.Middleware, error) {
+	secrets := make(map[string]config.Secret, len(entry.Secrets))
+	for _, s := range entry.Secrets {
+		if s.Name == "" || (s.Env == "" && s.File == "") {
+			return nil, fmt.Errorf("secret %q: name and env or file are required", s.Name)
+		}
+		if len(s.Methods) == 0 {
+			return nil, fmt.Errorf("secret %q: methods is required", s.Name)
+		}
+		secrets[s.Name] = s
+	}
+
+	return func(next executor.Handler) executor.Handler {
+		return func(ctx context.Context, call *executor.Call, sink executor.Sink) error {
+			// 组合方法的步骤会再次经过中间件，由步骤按自身方法替换
+			if !secretRef.MatchString(call.Params) || isComposite(call.Method) {
+				return next(ctx, call, sink)
+			}
+
+			// JSON 参数中的密钥按字符串内容转义，避免破坏 JSON 结构；shell 命令与脚本中按单引号转义，避免注入
+			isShell := call.Method == pb.Method_SHELL.String() || call.Method == pb.Method_SH_INTERP.String()
+			isJSON := !isShell && json.Valid([]byte(call.Params))
+			var (
+				values  []string
+				lookErr error
+			)
+			call.Params = secretRef.ReplaceAllStringFunc(call.Params, func(ref string) string {
+				name := secretRef.FindStringSubmatch(ref)[1]
+				value, err := readSecret(secrets, name, call.Method)
+				if err != nil {
+					if lookErr == nil {
+						lookErr = err
+					}
+					return ref
+				}
+				if value != "" {
+					values = append(values, value)
+				}
+				switch {
+				case isShell:
+					return shellQuote(value)
+				case isJSON:
+					quoted, _ := json.Marshal(value)
+					return string(quoted[1 : len(quoted)-1])
+				}
+				return value
+			})
+			if lookErr != nil {
+				return lookErr
+			}
+
+			patterns := make([]*regexp.Regexp, len(values))
+			for i, value := range values {
+				patterns[i] = regexp.MustCompile(regexp.QuoteMeta(value))
+			}
+			err := next(ctx, call, executor.ChainSink(sink, executor.MaskLines(patterns, maskedSecret)))
+			return maskError(err, values)
+		}
+	}, nil
+}
+
+// readSecret 读取密钥值，方法不在密钥的 methods 中时拒绝
+func readSecret(secrets map[string]config.Secret, name, method string) (string, error) {
+	s, ok := secrets[name]
+	if !ok {
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("unknown secret %q", name))
+	}
+	if !slices.Contains(s.Methods, method) {
+		return "", status.Error(codes.PermissionDenied, fmt.Sprintf("secret %q is not allowed for method %s", name, method))
+	}
+	if s.File != "" {
+		data, err := os.ReadFile(s.File)
+		if err != nil {
+			return "", status.Error(codes.InvalidArgument, fmt.Sprintf("read secret %q failed", name))
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+	value, ok := os.LookupEnv(s.Env)
+	if !ok {
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("secret %q is not set", name))
+	}
+	return value, nil
+}
+
+// isComposite 是否为由其他方法组成的方法
+func isComposite(method string) bool {
+	return method == pb.Method_PIPELINE.String() || method == pb.Method_DAG.String()
+}
+
+// shellQuote 按 POSIX shell 单引号规则转义，值中的单引号先结束引号再以反斜杠转义
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// maskError 遮蔽错误信息中的密钥，保留状态码与详情
+func maskError(err error, values []string) error {
+	if err == nil {
+		return nil
+	}
+	st := status.Convert(err).Proto()
+	for _, value := range values {
+		st.Message = strings.ReplaceAll(st.Message, value, maskedSecret)
+	}
+	return status.ErrorProto(st)
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"context"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/middleware/config"
+	"goumang-worker/services/pb"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestShellQuote(t *testing.T) {
+	values := []string{
+		"plain",
+		"",
+		"with space",
+		"it's",
+		`'\''`,
+		"$HOME `id` $(id)",
+		"a\nb",
+		`"; rm -rf / #`,
+	}
+	for _, value := range values {
+		out, err := exec.Command("sh", "-c", "printf %s "+shellQuote(value)).Output()
+		if err != nil {
+			t.Fatalf("sh %q: %v", value, err)
+		}
+		if string(out) != value {
+			t.Errorf("shellQuote(%q) evaluates to %q", value, out)
+		}
+	}
+}
+
+// recordSink 记录所有输出，消息按 JSON 保存
+type recordSink struct {
+	lines []string
+}
+
+func (s *recordSink) Stdout(line string) error { s.lines = append(s.lines, line); return nil }
+func (s *recordSink) Stderr(line string) error { s.lines = append(s.lines, line); return nil }
+func (s *recordSink) Event(event *pb.TaskEvent) error {
+	s.lines = append(s.lines, protojson.Format(event))
+	return nil
+}
+func (s *recordSink) Heartbeat(heartbeat *pb.Heartbeat) error {
+	s.lines = append(s.lines, protojson.Format(heartbeat))
+	return nil
+}
+func (s *recordSink) Result(result *pb.TaskResult) error {
+	s.lines = append(s.lines, protojson.Format(result))
+	return nil
+}
+
+func TestSecrets(t *testing.T) {
+	const value = `s3cr"et`
+	t.Setenv("GOUMANG_TEST_SECRET", value)
+	mw, err := newSecrets(config.Entry{Secrets: []config.Secret{
+		{Name: "tok", Env: "GOUMANG_TEST_SECRET", Methods: []string{pb.Method_SHELL.String(), pb.Method_HTTP.String()}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		params     string
+		wantParams string
+		wantCode   codes.Code
+	}{
+		{
+			name:       "shell quoted",
+			method:     pb.Method_SHELL.String(),
+			params:     "echo ${secret:tok}",
+			wantParams: `echo 's3cr"et'`,
+		},
+		{
+			name:       "json escaped",
+			method:     pb.Method_HTTP.String(),
+			params:     `{"token":"${secret:tok}"}`,
+			wantParams: `{"token":"s3cr\"et"}`,
+		},
+		{
+			name:     "method not allowed",
+			method:   pb.Method_SQL.String(),
+			params:   `{"dsn":"${secret:tok}"}`,
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "unknown secret",
+			method:   pb.Method_SHELL.String(),
+			params:   "echo ${secret:nope}",
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotParams string
+			// 执行器把密钥写入输出、事件、结果与错误信息
+			handler := mw(func(_ context.Context, call *executor.Call, sink executor.Sink) error {
+				gotParams = call.Params
+				_ = sink.Stdout("out " + value)
+				_ = sink.Event(&pb.TaskEvent{Event: &pb.TaskEvent_Status{Status: "status " + value}})
+				_ = sink.Event(&pb.TaskEvent{Event: &pb.TaskEvent_Metrics{Metrics: &pb.Metrics{Values: map[string]string{"k": value}}}})
+				_ = sink.Result(&pb.TaskResult{Artifacts: []*pb.Artifact{{Path: value}}})
+				return status.Error(codes.Unknown, "failed with "+value)
+			})
+			sink := &recordSink{}
+			err := handler(context.Background(), &executor.Call{Method: tt.method, Params: tt.params}, sink)
+
+			if tt.wantCode != codes.OK {
+				if status.Code(err) != tt.wantCode {
+					t.Errorf("error = %v, want code %v", err, tt.wantCode)
+				}
+				return
+			}
+			if gotParams != tt.wantParams {
+				t.Errorf("params = %q, want %q", gotParams, tt.wantParams)
+			}
+			if len(sink.lines) != 4 {
+				t.Fatalf("got %d outputs, want 4", len(sink.lines))
+			}
+			for _, line := range append(sink.lines, err.Error()) {
+				if strings.Contains(line, "s3cr") {
+					t.Errorf("secret leaked: %s", line)
+				}
+			}
+		})
+	}
+}
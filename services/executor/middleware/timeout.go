@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"context"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/middleware/config"
+	"time"
+)
+
+// newTimeout 超时中间件：按方法限制执行时长，只会缩短请求本身的超时
+func newTimeout(entry config.Entry) (executor.Middleware, error) {
+	perMethod := make(map[string]time.Duration, len(entry.Methods))
+	for _, m := range entry.Methods {
+		perMethod[m.Name] = time.Duration(m.TimeoutSec) * time.Second
+	}
+	defaultTimeout := time.Duration(entry.TimeoutSec) * time.Second
+
+	return func(next executor.Handler) executor.Handler {
+		return func(ctx context.Context, call *executor.Call, sink executor.Sink) error {
+			timeout, ok := perMethod[call.Method]
+			if !ok {
+				timeout = defaultTimeout
+			}
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			return next(ctx, call, sink)
+		}
+	}, nil
+}
@@ -0,0 +1,121 @@
+package executor
+
+import (
+	"bytes"
+	"goumang-worker/services/pb"
+	"sync"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// OutputSender 串行化多个 goroutine 对 Sink 的输出，并统计输出量
+type OutputSender struct {
+	mu     sync.Mutex
+	sink   Sink
+	result pb.TaskResult
+	err    error
+}
+
+// NewOutputSender 创建输出发送器
+func NewOutputSender(sink Sink) *OutputSender {
+	return &OutputSender{sink: sink}
+}
+
+// SendLine 发送一行输出，发送失败后不再继续发送
+func (s *OutputSender) SendLine(line string, isErr bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return
+	}
+
+	s.result.TotalBytes += uint64(len(line)) + 1
+	s.result.TotalLines++
+
+	if isErr {
+		s.err = s.sink.Stderr(line)
+	} else {
+		s.err = s.sink.Stdout(line)
+	}
+	if s.err == nil {
+		s.result.ForwardedBytes += uint64(len(line)) + 1
+		s.result.ForwardedLines++
+	}
+}
+
+// SendEvent 发送结构化事件，不计入输出统计
+func (s *OutputSender) SendEvent(event *pb.TaskEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return
+	}
+	s.err = s.sink.Event(event)
+}
+
+// Err 返回首次发送失败的错误
+func (s *OutputSender) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Result 返回输出统计结果
+func (s *OutputSender) Result() *pb.TaskResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return proto.Clone(&s.result).(*pb.TaskResult)
+}
+
+// maxLineBytes LineWriter 单行最大字节数
+const maxLineBytes = 64 * 1024
+
+// LineWriter 将写入内容按行转发，可作为子进程或解释器的 stdout/stderr
+type LineWriter struct {
+	mu     sync.Mutex
+	sender *OutputSender
+	isErr  bool
+	buf    []byte
+}
+
+// NewLineWriter 创建按行转发的 Writer，isErr 为 true 时作为 stderr 发送
+func NewLineWriter(sender *OutputSender, isErr bool) *LineWriter {
+	return &LineWriter{sender: sender, isErr: isErr}
+}
+
+// Write 实现 io.Writer，超长行按 maxLineBytes 拆分，避免无换行的输出无限累积
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		switch {
+		case idx >= 0:
+			w.sender.SendLine(string(w.buf[:idx]), w.isErr)
+			w.buf = w.buf[idx+1:]
+		case len(w.buf) > maxLineBytes:
+			// 在字符边界拆分，避免截断多字节字符
+			cut := maxLineBytes
+			for cut > maxLineBytes-utf8.UTFMax && !utf8.RuneStart(w.buf[cut]) {
+				cut--
+			}
+			w.sender.SendLine(string(w.buf[:cut]), w.isErr)
+			w.buf = w.buf[cut:]
+		default:
+			return len(p), nil
+		}
+	}
+}
+
+// Flush 发送剩余的不完整行
+func (w *LineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.sender.SendLine(string(w.buf), w.isErr)
+		w.buf = nil
+	}
+}
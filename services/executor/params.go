@@ -0,0 +1,177 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ParamsSpec 方法参数声明
+type ParamsSpec struct {
+	// 参数版本，参数结构发生不兼容变更时递增
+	Version uint32
+	// 方法说明
+	Description string
+	// 参数类型的零值：JSON 参数传结构体，如 Params{}，执行器通过 DecodeParams 取得工厂解码的结果；纯文本参数（如 shell 命令）传 ""
+	Params any
+	// 直接提供的 Schema（如插件声明的参数），设置时忽略 Params，只按 Schema 校验；不限制类型的空 Schema 表示不校验内容
+	Schema *Schema
+}
+
+// MethodDescription 方法说明，供调度端渲染参数表单
+type MethodDescription struct {
+	Name        string
+	Version     uint32
+	Description string
+	// 根类型为 string 时参数为纯文本，否则为 JSON
+	Schema *Schema
+}
+
+// methodParams 已注册的参数声明及其 Schema
+type methodParams struct {
+	spec   ParamsSpec
+	schema *Schema
+	// 参数类型，直接提供 Schema 时为 nil
+	typ reflect.Type
+}
+
+// newMethodParams 生成参数 Schema
+func newMethodParams(spec ParamsSpec) *methodParams {
+	if spec.Schema != nil {
+		if err := spec.Schema.Check(); err != nil {
+			panic(err)
+		}
+		return &methodParams{spec: spec, schema: spec.Schema}
+	}
+	t := reflect.TypeOf(spec.Params)
+	if t == nil {
+		panic(errors.New("params type or schema is required"))
+	}
+	return &methodParams{spec: spec, schema: SchemaOf(spec.Params), typ: t}
+}
+
+// isText 参数是否为纯文本
+func (m *methodParams) isText() bool {
+	return m.schema.Type == "string"
+}
+
+// validate 校验参数版本与内容，JSON 参数按 Schema 校验后再解码到参数类型，确保执行器能够解析
+func (m *methodParams) validate(version uint32, params string) error {
+	if version != 0 && version != m.spec.Version {
+		return status.Error(codes.InvalidArgument,
+			fmt.Sprintf("params version %d is not supported, expected %d", version, m.spec.Version))
+	}
+	if m.isText() {
+		return fieldErrors(m.schema.Validate(params))
+	}
+	// 直接提供且不限制类型的 Schema，参数可以是任意文本
+	if m.typ == nil && m.schema.Type == "" {
+		return nil
+	}
+
+	var v any
+	dec := json.NewDecoder(strings.NewReader(params))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid params: %v", err))
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return status.Error(codes.InvalidArgument, "invalid params: unexpected data after JSON value")
+	}
+	if errs := m.schema.Validate(v); len(errs) > 0 {
+		return fieldErrors(errs)
+	}
+
+	_, err := m.decode(params)
+	return err
+}
+
+// decodes 是否按参数类型解码，纯文本参数与直接提供 Schema 的参数不解码
+func (m *methodParams) decodes() bool {
+	return m.typ != nil && !m.isText()
+}
+
+// decode 将 JSON 参数解码为参数类型的指针，不解码的参数返回 nil
+func (m *methodParams) decode(params string) (any, error) {
+	if !m.decodes() {
+		return nil, nil
+	}
+	target := reflect.New(m.typ).Interface()
+	if err := json.Unmarshal([]byte(params), target); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, fieldErrors([]FieldError{{Field: typeErr.Field, Message: "must be " + typeErr.Type.String()}})
+		}
+		return nil, fieldErrors([]FieldError{{Message: err.Error()}})
+	}
+	return target, nil
+}
+
+// decodedParamsKey 上下文中已解码参数的键
+type decodedParamsKey struct{}
+
+// decodedParams 已解码的参数及其原文
+type decodedParams struct {
+	raw   string
+	value any
+}
+
+// paramsExecutor 执行前按声明的参数类型解码，解码结果经上下文交给执行器，避免执行器各自解析
+type paramsExecutor struct {
+	exec   Executor
+	params *methodParams
+}
+
+// Execute 实现 Executor，参数经中间件（如密钥注入）处理后在此解码
+func (e *paramsExecutor) Execute(ctx context.Context, params string, sink Sink) error {
+	value, err := e.params.decode(params)
+	if err != nil {
+		return err
+	}
+	return e.exec.Execute(context.WithValue(ctx, decodedParamsKey{}, &decodedParams{raw: params, value: value}), params, sink)
+}
+
+// DecodeParams 返回执行器的参数：由工厂创建的执行器直接使用工厂解码的结果，否则按 JSON 解码
+func DecodeParams[T any](ctx context.Context, params string) (*T, error) {
+	if d, ok := ctx.Value(decodedParamsKey{}).(*decodedParams); ok && d.raw == params {
+		if value, ok := d.value.(*T); ok {
+			return value, nil
+		}
+	}
+	value := new(T)
+	if err := json.Unmarshal([]byte(params), value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// fieldErrors 转换为 InvalidArgument 错误，字段错误放在 BadRequest 详情中
+func fieldErrors(errs []FieldError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	messages := make([]string, len(errs))
+	violations := make([]*errdetails.BadRequest_FieldViolation, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Message
+		if e.Field != "" {
+			messages[i] = e.Field + ": " + e.Message
+		}
+		violations[i] = &errdetails.BadRequest_FieldViolation{Field: e.Field, Description: e.Message}
+	}
+
+	st := status.New(codes.InvalidArgument, "invalid params: "+strings.Join(messages, "; "))
+	withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
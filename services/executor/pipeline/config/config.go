@@ -0,0 +1,50 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// PipelineExecutorConfig 流水线执行器配置
+type PipelineExecutorConfig struct {
+	// 单个流水线最多步骤数
+	MaxSteps int `yaml:"maxSteps"`
+	// 共享工作目录的根目录
+	WorkspaceRoot string `yaml:"workspaceRoot"`
+}
+
+// Config 流水线配置结构
+type Config struct {
+	Pipeline PipelineExecutorConfig `yaml:"pipeline"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "pipeline.yaml"), &globalConfig); err != nil {
+			panic("loadConfig pipeline.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if globalConfig.Pipeline.MaxSteps <= 0 {
+			globalConfig.Pipeline.MaxSteps = 50
+		}
+		if globalConfig.Pipeline.WorkspaceRoot == "" {
+			globalConfig.Pipeline.WorkspaceRoot = path.Join(env.RootPath(), "data", "pipelines")
+		}
+	})
+}
+
+// GetPipelineConfig 获取流水线执行器配置
+func GetPipelineConfig() PipelineExecutorConfig {
+	lazyLoadConfig()
+	return globalConfig.Pipeline
+}
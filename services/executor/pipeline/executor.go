@@ -0,0 +1,168 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"goumang-worker/services/artifact"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/pipeline/config"
+	"goumang-worker/services/executor/step"
+	"goumang-worker/services/pb"
+	"os"
+	"strconv"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// init 自动注册流水线执行器到默认工厂
+func init() {
+	executor.RegisterExecutor(pb.Method_PIPELINE.String(), NewExecutor)
+	executor.RegisterParams(pb.Method_PIPELINE.String(), executor.ParamsSpec{
+		Version:     1,
+		Description: "Sequential steps sharing env and working directory",
+		Params:      Params{},
+	})
+}
+
+// Params 流水线参数（method_params 的 JSON 内容）
+type Params struct {
+	// 按顺序执行的步骤
+	Steps []Step `json:"steps" schema:"required"`
+	// 所有步骤共享的环境变量
+	Env map[string]string `json:"env"`
+}
+
+// Step 流水线步骤
+type Step struct {
+	// 步骤名称，用于事件与错误信息
+	Name string `json:"name"`
+	// 方法名，如 "SHELL"、"HTTP"
+	Method string `json:"method" schema:"required,minLength=1"`
+	// 方法参数：字符串原样传递，对象或数组按 JSON 文本传递
+	Params json.RawMessage `json:"params"`
+	// 步骤超时秒数，0 表示只受整个任务超时限制
+	TimeoutSec int `json:"timeoutSec" schema:"min=0"`
+	// 失败后是否继续执行后续步骤
+	ContinueOnError bool `json:"continueOnError"`
+}
+
+// plannedStep 校验后的步骤
+type plannedStep struct {
+	Step
+	inv step.Invocation
+}
+
+// Executor 流水线执行器
+type Executor struct{}
+
+// NewExecutor 创建新的流水线执行器
+func NewExecutor() executor.Executor {
+	return &Executor{}
+}
+
+// Execute 在同一个输出中按顺序执行各步骤，步骤前后发送 step_start/step_end 事件
+func (e *Executor) Execute(ctx context.Context, params string, sink executor.Sink) error {
+	p, err := executor.DecodeParams[Params](ctx, params)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid params: %v", err))
+	}
+	cfg := config.GetPipelineConfig()
+	steps, err := plan(cfg, p.Steps)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 共享工作目录
+	taskInfo := executor.TaskInfoFromContext(ctx)
+	if err = os.MkdirAll(cfg.WorkspaceRoot, 0o750); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("create workspace root failed: %v", err))
+	}
+	workDir, err := os.MkdirTemp(cfg.WorkspaceRoot, strconv.FormatUint(taskInfo.RunTaskID, 10)+"-")
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("create workspace failed: %v", err))
+	}
+	defer func() {
+		if errR := os.RemoveAll(workDir); errR != nil {
+			logit.Context(ctx).WarnW("pipeline.RemoveAll.Err", errR)
+		}
+	}()
+
+	// 产物在流水线结束后统一收集，步骤内不重复收集
+	stepCtx := executor.WithTaskInfo(ctx, &executor.TaskInfo{
+		RunTaskID: taskInfo.RunTaskID,
+		WorkDir:   workDir,
+		Env:       append(append([]string{}, taskInfo.Env...), step.EnvList(p.Env)...),
+	})
+
+	result := &pb.TaskResult{}
+	var runErr error
+	for i, s := range steps {
+		index := int32(i)
+		if err = sink.Event(&pb.TaskEvent{Event: &pb.TaskEvent_StepStart{StepStart: &pb.StepStart{
+			Index:      index,
+			Name:       s.Name,
+			Method:     executor.MethodEnum(s.inv.Method),
+			MethodName: s.inv.Method,
+		}}}); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to send output: %v", err))
+		}
+
+		out := step.Run(stepCtx, s.inv, step.NewStream(sink, ""))
+		step.MergeResult(result, out.Result)
+		if err = sink.Event(&pb.TaskEvent{Event: &pb.TaskEvent_StepEnd{StepEnd: &pb.StepEnd{
+			Index:      index,
+			Name:       s.Name,
+			ExitCode:   out.ExitCode,
+			Error:      out.Error,
+			DurationMs: out.DurationMs,
+			Result:     out.Result,
+		}}}); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to send output: %v", err))
+		}
+
+		if out.Err == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			runErr = status.Error(codes.Internal, fmt.Sprintf("pipeline canceled or timeout: %v", ctx.Err()))
+			break
+		}
+		if !s.ContinueOnError {
+			runErr = step.WrapError(fmt.Sprintf("step %d (%s) failed", i, s.Name), out.Err)
+			break
+		}
+	}
+
+	result.Artifacts = artifact.Collect(ctx, taskInfo.RunTaskID, workDir, taskInfo.Artifacts)
+	if errS := sink.Result(result); errS != nil {
+		logit.Context(ctx).WarnW("result.stream.Send.Err", errS)
+	}
+	return runErr
+}
+
+// plan 校验步骤并解析方法与参数，任何步骤不合法时整个流水线不执行
+func plan(cfg config.PipelineExecutorConfig, steps []Step) ([]plannedStep, error) {
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no steps")
+	}
+	if len(steps) > cfg.MaxSteps {
+		return nil, fmt.Errorf("too many steps: %d > %d", len(steps), cfg.MaxSteps)
+	}
+
+	planned := make([]plannedStep, 0, len(steps))
+	for i, s := range steps {
+		if s.Name == "" {
+			s.Name = strconv.Itoa(i)
+		}
+		// 不允许嵌套编排
+		inv, err := step.Resolve(s.Method, s.Params, s.TimeoutSec, pb.Method_PIPELINE.String(), pb.Method_DAG.String())
+		if err != nil {
+			return nil, fmt.Errorf("step %d (%s): %v", i, s.Name, err)
+		}
+		planned = append(planned, plannedStep{Step: s, inv: inv})
+	}
+	return planned, nil
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// PluginConfig 插件配置
+type PluginConfig struct {
+	// 是否启用插件
+	Enabled bool `yaml:"enabled"`
+	// 插件目录，目录下的每个可执行文件作为一个插件启动
+	Dir string `yaml:"dir"`
+	// 握手超时秒数
+	HandshakeTimeoutSec int `yaml:"handshakeTimeoutSec"`
+	// 插件退出后重启的初始等待秒数，连续失败时翻倍
+	RestartBackoffSec int `yaml:"restartBackoffSec"`
+	// 重启等待秒数上限
+	MaxRestartBackoffSec int `yaml:"maxRestartBackoffSec"`
+	// 任务取消后等待插件结束任务的秒数
+	CancelGraceSec int `yaml:"cancelGraceSec"`
+	// 协议单行最大字节数
+	MaxLineBytes int `yaml:"maxLineBytes"`
+}
+
+// Config 插件配置结构
+type Config struct {
+	Plugin PluginConfig `yaml:"plugin"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "plugin.yaml"), &globalConfig); err != nil {
+			panic("loadConfig plugin.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if globalConfig.Plugin.Dir == "" {
+			globalConfig.Plugin.Dir = path.Join(env.RootPath(), "plugins")
+		}
+		if globalConfig.Plugin.HandshakeTimeoutSec <= 0 {
+			globalConfig.Plugin.HandshakeTimeoutSec = 10
+		}
+		if globalConfig.Plugin.RestartBackoffSec <= 0 {
+			globalConfig.Plugin.RestartBackoffSec = 1
+		}
+		if globalConfig.Plugin.MaxRestartBackoffSec <= 0 {
+			globalConfig.Plugin.MaxRestartBackoffSec = 60
+		}
+		if globalConfig.Plugin.MaxRestartBackoffSec < globalConfig.Plugin.RestartBackoffSec {
+			globalConfig.Plugin.MaxRestartBackoffSec = globalConfig.Plugin.RestartBackoffSec
+		}
+		if globalConfig.Plugin.CancelGraceSec <= 0 {
+			globalConfig.Plugin.CancelGraceSec = 5
+		}
+		if globalConfig.Plugin.MaxLineBytes <= 0 {
+			globalConfig.Plugin.MaxLineBytes = 1024 * 1024
+		}
+	})
+}
+
+// GetPluginConfig 获取插件配置
+func GetPluginConfig() PluginConfig {
+	lazyLoadConfig()
+	return globalConfig.Plugin
+}
@@ -0,0 +1,124 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/plugin/config"
+	"goumang-worker/services/pb"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Executor 将任务转发给实现该方法的插件进程
+type Executor struct {
+	method string
+}
+
+// Execute 发送任务到插件并转发插件输出，ctx 结束时通知插件取消并等待其结束
+func (e *Executor) Execute(ctx context.Context, params string, sink executor.Sink) error {
+	p, ok := lookup(e.method)
+	if !ok {
+		return status.Error(codes.Unavailable, fmt.Sprintf("plugin for method %s is not running", e.method))
+	}
+
+	taskInfo := executor.TaskInfoFromContext(ctx)
+	req := &message{
+		Method:    e.method,
+		Params:    params,
+		RunTaskID: taskInfo.RunTaskID,
+		WorkDir:   taskInfo.WorkDir,
+		Env:       taskInfo.Env,
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		req.DeadlineMs = deadline.UnixMilli()
+	}
+	id, ch, err := p.run(req)
+	if err != nil {
+		return status.Error(codes.Unavailable, fmt.Sprintf("plugin %s: %v", p.name, err))
+	}
+
+	sender := executor.NewOutputSender(sink)
+	var (
+		grace <-chan time.Time
+		done  *message
+	)
+	ctxDone := ctx.Done()
+loop:
+	for {
+		select {
+		case msg, open := <-ch:
+			if !open {
+				break loop
+			}
+			if msg.Type == msgDone {
+				done = msg
+				break loop
+			}
+			e.forward(ctx, sender, msg)
+		case <-ctxDone:
+			// 通知插件取消，在宽限期内继续转发输出
+			p.cancel(id)
+			ctxDone = nil
+			grace = time.After(time.Duration(config.GetPluginConfig().CancelGraceSec) * time.Second)
+		case <-grace:
+			p.forget(id)
+			break loop
+		}
+	}
+
+	if errS := sink.Result(sender.Result()); errS != nil {
+		logit.Context(ctx).WarnW("result.stream.Send.Err", errS)
+	}
+	switch {
+	case ctx.Err() != nil:
+		return status.Error(codes.Internal, fmt.Sprintf("plugin task canceled or timeout: %v", ctx.Err()))
+	case done == nil:
+		return status.Error(codes.Unavailable, fmt.Sprintf("plugin %s: %v", p.name, errCrashed))
+	case sender.Err() != nil:
+		return status.Error(codes.Internal, fmt.Sprintf("failed to send output: %v", sender.Err()))
+	}
+	return doneError(done)
+}
+
+// forward 转发插件输出
+func (e *Executor) forward(ctx context.Context, sender *executor.OutputSender, msg *message) {
+	switch msg.Type {
+	case msgStdout:
+		sender.SendLine(msg.Line, false)
+	case msgStderr:
+		sender.SendLine(msg.Line, true)
+	case msgEvent:
+		event := &pb.TaskEvent{}
+		if err := protojson.Unmarshal(msg.Event, event); err != nil {
+			logit.Context(ctx).WarnW("plugin.event.Err", err, "method", e.method)
+			return
+		}
+		sender.SendEvent(event)
+	}
+}
+
+// doneError 将插件的结束消息转换为执行错误
+func doneError(done *message) error {
+	if done.ExitCode != 0 {
+		msg := done.Error
+		if msg == "" {
+			msg = fmt.Sprintf("plugin task exited with code %d", done.ExitCode)
+		}
+		return executor.ExitStatusError(int(done.ExitCode), msg)
+	}
+	if done.Error == "" {
+		return nil
+	}
+	code := codes.Internal
+	if done.Code != "" {
+		if errU := code.UnmarshalJSON([]byte(`"` + done.Code + `"`)); errU != nil || code == codes.OK {
+			code = codes.Internal
+		}
+	}
+	return status.Error(code, done.Error)
+}
@@ -0,0 +1,157 @@
+package plugin
+
+import (
+	"context"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/plugin/config"
+	"goumang-worker/services/pb"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/core/gtask"
+	"github.com/bpcoder16/Chestnut/v2/logit"
+)
+
+// stableRunDuration 插件运行超过该时长后退出，重启等待时间重置
+const stableRunDuration = time.Minute
+
+var (
+	// running 方法名到当前插件进程，插件重启期间不存在
+	running   = make(map[string]*process)
+	runningMu sync.RWMutex
+	// owners 方法名到首个声明它的插件名，其他插件不能再声明
+	owners = make(map[string]string)
+)
+
+// Run 启动插件目录下的所有插件并持续监管，插件退出后按退避时间重启，ctx 结束时停止所有插件
+func Run(ctx context.Context) error {
+	cfg := config.GetPluginConfig()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	// 插件以所在目录为工作目录启动，路径需为绝对路径
+	dir, err := filepath.Abs(cfg.Dir)
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		logit.Context(ctx).WarnW("plugin.ReadDir.Err", err, "dir", dir)
+		return nil
+	}
+
+	g, ctx := gtask.WithContext(ctx)
+	for _, entry := range entries {
+		info, errI := entry.Info()
+		if errI != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		g.Go(func() error {
+			supervise(ctx, cfg, path)
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+// supervise 运行单个插件，退出后重启
+func supervise(ctx context.Context, cfg config.PluginConfig, path string) {
+	backoff := time.Duration(cfg.RestartBackoffSec) * time.Second
+	maxBackoff := time.Duration(cfg.MaxRestartBackoffSec) * time.Second
+	wait := backoff
+
+	for ctx.Err() == nil {
+		start := time.Now()
+		p, err := startProcess(ctx, cfg, path)
+		if err != nil {
+			logit.Context(ctx).WarnW("plugin.start.Err", err, "plugin", filepath.Base(path))
+		} else {
+			logit.Context(ctx).InfoW("logType", "plugin started", "plugin", p.name, "methods", len(p.methods))
+			attach(ctx, p)
+			<-p.done
+			detach(p)
+		}
+
+		if time.Since(start) > stableRunDuration {
+			wait = backoff
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		wait = min(wait*2, maxBackoff)
+	}
+}
+
+// attach 将插件声明的方法指向该进程并注册到执行器工厂，方法名归首个声明它的插件所有
+func attach(ctx context.Context, p *process) {
+	runningMu.Lock()
+	defer runningMu.Unlock()
+
+	seen := make(map[string]bool, len(p.methods))
+	for _, m := range p.methods {
+		owner, claimed := owners[m.Name]
+		switch {
+		case m.Name == "" || m.Name == pb.Method_OTHER.String():
+			// OTHER 只用于标记枚举外的方法
+			continue
+		case seen[m.Name]:
+			logit.Context(ctx).WarnW("logType", "plugin method duplicated", "plugin", p.name, "method", m.Name)
+			continue
+		case claimed && owner != p.name:
+			logit.Context(ctx).WarnW("logType", "plugin method conflict", "plugin", p.name, "method", m.Name, "owner", owner)
+			continue
+		case !claimed && executor.IsSupported(m.Name):
+			// 不能覆盖内置执行器
+			logit.Context(ctx).WarnW("logType", "plugin method conflict", "plugin", p.name, "method", m.Name, "owner", "builtin")
+			continue
+		}
+		if m.Schema != nil {
+			if err := m.Schema.Check(); err != nil {
+				logit.Context(ctx).WarnW("logType", "plugin method schema invalid", "plugin", p.name, "method", m.Name, "reason", err.Error())
+				continue
+			}
+		}
+		seen[m.Name] = true
+		running[m.Name] = p
+		owners[m.Name] = p.name
+
+		// 每次握手重新注册，插件重启后以新声明的版本与参数为准
+		name := m.Name
+		executor.RegisterExecutor(name, func() executor.Executor {
+			return &Executor{method: name}
+		})
+		// 未声明 Schema 时不校验参数
+		schema := m.Schema
+		if schema == nil {
+			schema = &executor.Schema{}
+		}
+		executor.RegisterParams(name, executor.ParamsSpec{Version: m.Version, Description: m.Description, Schema: schema})
+	}
+}
+
+// detach 插件退出后移除方法指向并从执行器工厂注销，重启前方法不再对外声明
+func detach(p *process) {
+	runningMu.Lock()
+	defer runningMu.Unlock()
+
+	for name, owner := range running {
+		if owner == p {
+			delete(running, name)
+			executor.UnregisterExecutor(name)
+		}
+	}
+}
+
+// lookup 查找实现方法的插件进程
+func lookup(method string) (*process, bool) {
+	runningMu.RLock()
+	defer runningMu.RUnlock()
+	p, ok := running[method]
+	return p, ok
+}
@@ -0,0 +1,264 @@
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor/plugin/config"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+)
+
+// errCrashed 插件进程已退出
+var errCrashed = errors.New("plugin process exited")
+
+// task 插件中执行的任务，ch 只由读取协程写入与关闭
+type task struct {
+	ch   chan *message
+	quit chan struct{}
+}
+
+// process 一个运行中的插件进程
+type process struct {
+	name    string
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	writeMu sync.Mutex
+	methods []MethodInfo
+
+	mu     sync.Mutex
+	nextID uint64
+	tasks  map[uint64]*task
+	exited bool
+	// done 进程退出后关闭
+	done chan struct{}
+}
+
+// startProcess 启动插件并完成握手
+func startProcess(ctx context.Context, cfg config.PluginConfig, path string) (*process, error) {
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Dir = filepath.Dir(path)
+	cmd.Env = append(cmd.Environ(), fmt.Sprintf("GOUMANG_PLUGIN_PROTOCOL=%d", protocolVersion))
+	// worker 退出时先发 SIGTERM，超时后强杀
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = time.Duration(cfg.CancelGraceSec) * time.Second
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	p := &process{
+		name:  filepath.Base(path),
+		cmd:   cmd,
+		stdin: stdin,
+		tasks: make(map[uint64]*task),
+		done:  make(chan struct{}),
+	}
+	cmd.Stderr = &logWriter{ctx: ctx, name: p.name}
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), cfg.MaxLineBytes)
+	hello, err := p.handshake(scanner, time.Duration(cfg.HandshakeTimeoutSec)*time.Second)
+	if err != nil {
+		_ = stdin.Close()
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("handshake failed: %w", err)
+	}
+	p.methods = hello.Methods
+
+	go p.readLoop(ctx, scanner)
+	return p, nil
+}
+
+// handshake 读取插件的 hello 消息
+func (p *process) handshake(scanner *bufio.Scanner, timeout time.Duration) (*message, error) {
+	type result struct {
+		msg *message
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		if !scanner.Scan() {
+			err := scanner.Err()
+			if err == nil {
+				err = io.EOF
+			}
+			ch <- result{err: err}
+			return
+		}
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			ch <- result{err: err}
+			return
+		}
+		ch <- result{msg: &msg}
+	}()
+
+	select {
+	case r := <-ch:
+		if r.err != nil {
+			return nil, r.err
+		}
+		if r.msg.Type != msgHello {
+			return nil, fmt.Errorf("expected hello, got %q", r.msg.Type)
+		}
+		if r.msg.Protocol != protocolVersion {
+			return nil, fmt.Errorf("unsupported protocol version %d", r.msg.Protocol)
+		}
+		if len(r.msg.Methods) == 0 {
+			return nil, errors.New("no methods declared")
+		}
+		return r.msg, nil
+	case <-time.After(timeout):
+		// 超时后由调用方结束进程，Scan 随之返回
+		return nil, errors.New("timeout")
+	}
+}
+
+// readLoop 读取插件输出并分发给对应任务，进程退出后结束所有任务
+func (p *process) readLoop(ctx context.Context, scanner *bufio.Scanner) {
+	for scanner.Scan() {
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			logit.Context(ctx).WarnW("plugin.invalidMessage", err, "plugin", p.name)
+			continue
+		}
+		p.mu.Lock()
+		t, ok := p.tasks[msg.ID]
+		if ok && msg.Type == msgDone {
+			delete(p.tasks, msg.ID)
+		}
+		p.mu.Unlock()
+		if !ok {
+			// 已结束或未知任务的消息直接丢弃
+			continue
+		}
+		select {
+		case t.ch <- &msg:
+		case <-t.quit:
+		}
+		if msg.Type == msgDone {
+			close(t.ch)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		logit.Context(ctx).WarnW("plugin.read.Err", err, "plugin", p.name)
+	}
+
+	p.mu.Lock()
+	p.exited = true
+	for id, t := range p.tasks {
+		close(t.ch)
+		delete(p.tasks, id)
+	}
+	p.mu.Unlock()
+
+	_ = p.stdin.Close()
+	err := p.cmd.Wait()
+	logit.Context(ctx).WarnW("logType", "plugin exited", "plugin", p.name, "err", fmt.Sprint(err))
+	close(p.done)
+}
+
+// run 发送任务，返回任务 id 与接收插件消息的通道，通道在任务结束或进程退出时关闭
+func (p *process) run(req *message) (uint64, <-chan *message, error) {
+	p.mu.Lock()
+	if p.exited {
+		p.mu.Unlock()
+		return 0, nil, errCrashed
+	}
+	p.nextID++
+	id := p.nextID
+	// 缓冲避免单个任务消费慢时阻塞其他任务的分发
+	t := &task{ch: make(chan *message, 256), quit: make(chan struct{})}
+	p.tasks[id] = t
+	p.mu.Unlock()
+
+	req.Type = msgRun
+	req.ID = id
+	if err := p.send(req); err != nil {
+		p.forget(id)
+		return 0, nil, err
+	}
+	return id, t.ch, nil
+}
+
+// cancel 通知插件取消任务
+func (p *process) cancel(id uint64) {
+	_ = p.send(&message{Type: msgCancel, ID: id})
+}
+
+// forget 停止接收任务消息，之后该任务的消息被丢弃
+func (p *process) forget(id uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if t, ok := p.tasks[id]; ok {
+		close(t.quit)
+		delete(p.tasks, id)
+	}
+}
+
+// send 写入一行消息
+func (p *process) send(msg *message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	if _, err = p.stdin.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("%w: %v", errCrashed, err)
+	}
+	return nil
+}
+
+// maxLogLine 插件 stderr 单行最大字节数
+const maxLogLine = 64 * 1024
+
+// logWriter 将插件 stderr 按行写入日志
+type logWriter struct {
+	ctx  context.Context
+	name string
+	buf  []byte
+}
+
+// Write 实现 io.Writer，超长行按 maxLogLine 拆分，避免无换行的输出无限累积
+func (w *logWriter) Write(b []byte) (int, error) {
+	w.buf = append(w.buf, b...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		switch {
+		case idx >= 0:
+			w.log(w.buf[:idx])
+			w.buf = w.buf[idx+1:]
+		case len(w.buf) >= maxLogLine:
+			w.log(w.buf[:maxLogLine])
+			w.buf = w.buf[maxLogLine:]
+		default:
+			return len(b), nil
+		}
+	}
+}
+
+// log 输出一行日志
+func (w *logWriter) log(line []byte) {
+	logit.Context(w.ctx).InfoW("logType", "plugin stderr", "plugin", w.name, "line", string(line))
+}
@@ -0,0 +1,69 @@
+package plugin
+
+import (
+	"encoding/json"
+	"goumang-worker/services/executor"
+)
+
+// 插件协议：worker 通过 stdin/stdout 与插件进程交换 JSON 行，插件的 stderr 写入 worker 日志
+//
+//	插件 -> worker  {"type":"hello","protocol":1,"methods":[{"name":"...","version":1,"description":"...","schema":{...}}]}
+//	worker -> 插件  {"type":"run","id":1,"method":"...","params":"...","runTaskId":7,"workDir":"...","env":["K=V"],"deadlineMs":1700000000000}
+//	插件 -> worker  {"type":"stdout","id":1,"line":"..."} / {"type":"stderr","id":1,"line":"..."}
+//	插件 -> worker  {"type":"event","id":1,"event":{...}}（TaskEvent 的 protojson）
+//	worker -> 插件  {"type":"cancel","id":1}
+//	插件 -> worker  {"type":"done","id":1,"exitCode":0,"error":"","code":""}
+//
+// 同一插件进程可并发执行多个任务，按 id 区分；worker 关闭 stdin 表示插件应退出
+const protocolVersion = 1
+
+const (
+	msgHello  = "hello"
+	msgRun    = "run"
+	msgCancel = "cancel"
+	msgStdout = "stdout"
+	msgStderr = "stderr"
+	msgEvent  = "event"
+	msgDone   = "done"
+)
+
+// MethodInfo 插件声明的方法
+type MethodInfo struct {
+	// 方法名
+	Name string `json:"name"`
+	// 参数版本
+	Version uint32 `json:"version"`
+	// 方法说明
+	Description string `json:"description"`
+	// 参数 JSON Schema，为空时不校验
+	Schema *executor.Schema `json:"schema"`
+}
+
+// message 协议消息，按 type 使用对应字段
+type message struct {
+	Type string `json:"type"`
+	ID   uint64 `json:"id,omitempty"`
+
+	// hello
+	Protocol int          `json:"protocol,omitempty"`
+	Methods  []MethodInfo `json:"methods,omitempty"`
+
+	// run
+	Method     string   `json:"method,omitempty"`
+	Params     string   `json:"params,omitempty"`
+	RunTaskID  uint64   `json:"runTaskId,omitempty"`
+	WorkDir    string   `json:"workDir,omitempty"`
+	Env        []string `json:"env,omitempty"`
+	DeadlineMs int64    `json:"deadlineMs,omitempty"`
+
+	// stdout/stderr
+	Line string `json:"line,omitempty"`
+
+	// event
+	Event json.RawMessage `json:"event,omitempty"`
+
+	// done：exitCode 非 0 时按退出码失败，否则 error 非空时按 code（gRPC 状态码名称）失败
+	ExitCode int32  `json:"exitCode,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Code     string `json:"code,omitempty"`
+}
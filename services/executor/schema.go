@@ -0,0 +1,283 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema 方法参数的 JSON Schema（draft 2020-12 子集），由参数类型反射生成
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+}
+
+// FieldError 字段级校验错误
+type FieldError struct {
+	// 字段路径，如 "steps[0].method"，根对象为空
+	Field   string
+	Message string
+}
+
+var (
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	bytesType      = reflect.TypeOf([]byte{})
+)
+
+// SchemaOf 根据参数类型生成 Schema
+// 字段名取自 json 标签，约束取自 schema 标签，如 `schema:"required,enum=GET|POST,min=0,max=10,minLength=1"`
+func SchemaOf(v any) *Schema {
+	return schemaOfType(reflect.TypeOf(v))
+}
+
+// schemaOfType 递归生成类型的 Schema，json.RawMessage 与 interface 不限制类型
+func schemaOfType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == rawMessageType:
+		return &Schema{}
+	case t == bytesType:
+		return &Schema{Type: "string", Format: "byte"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaOfType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaOfType(t.Elem())}
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		addFields(s, t)
+		sort.Strings(s.Required)
+		return s
+	default:
+		return &Schema{}
+	}
+}
+
+// addFields 添加结构体字段，匿名嵌入的结构体字段展开到外层
+func addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			addFields(s, f.Type)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		prop := schemaOfType(f.Type)
+		if applyTag(prop, f.Tag.Get("schema")) {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+}
+
+// applyTag 应用 schema 标签中的约束，返回字段是否必填
+func applyTag(s *Schema, tag string) (required bool) {
+	if tag == "" {
+		return false
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(opt, "=")
+		switch key {
+		case "required":
+			required = true
+		case "enum":
+			s.Enum = strings.Split(value, "|")
+		case "min", "max":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				panic(fmt.Errorf("invalid schema tag %q: %v", tag, err))
+			}
+			if key == "min" {
+				s.Minimum = &n
+			} else {
+				s.Maximum = &n
+			}
+		case "minLength":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				panic(fmt.Errorf("invalid schema tag %q: %v", tag, err))
+			}
+			s.MinLength = &n
+		default:
+			panic(fmt.Errorf("unknown schema tag option %q", opt))
+		}
+	}
+	return required
+}
+
+// Check 检查 Schema 本身是否合法，用于校验插件等外部提供的 Schema
+func (s *Schema) Check() error {
+	return s.check("")
+}
+
+// check 递归检查，path 为出错位置
+func (s *Schema) check(path string) error {
+	if s == nil {
+		return fmt.Errorf("schema%s is null", path)
+	}
+	switch s.Type {
+	case "", "string", "boolean", "integer", "number", "array", "object":
+	default:
+		return fmt.Errorf("schema%s: unsupported type %q", path, s.Type)
+	}
+	if s.MinLength != nil && *s.MinLength < 0 {
+		return fmt.Errorf("schema%s: negative minLength", path)
+	}
+	if s.Minimum != nil && s.Maximum != nil && *s.Minimum > *s.Maximum {
+		return fmt.Errorf("schema%s: minimum is greater than maximum", path)
+	}
+	if s.Items != nil {
+		if err := s.Items.check(path + ".items"); err != nil {
+			return err
+		}
+	}
+	if s.AdditionalProperties != nil {
+		if err := s.AdditionalProperties.check(path + ".additionalProperties"); err != nil {
+			return err
+		}
+	}
+	for name, prop := range s.Properties {
+		if err := prop.check(path + ".properties." + name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate 校验 JSON 解码后的值（数字需以 json.Number 解码），返回全部字段错误
+func (s *Schema) Validate(v any) []FieldError {
+	var errs []FieldError
+	s.validate("", v, &errs)
+	return errs
+}
+
+// validate 递归校验，null 视为未设置，Schema 为 nil 时不限制
+func (s *Schema) validate(field string, v any, errs *[]FieldError) {
+	if s == nil || v == nil {
+		return
+	}
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch s.Type {
+	case "":
+		return
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		if s.MinLength != nil && len(str) < *s.MinLength {
+			if *s.MinLength == 1 {
+				fail("must not be empty")
+			} else {
+				fail("must be at least %d characters", *s.MinLength)
+			}
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+			fail("must be one of %s", strings.Join(s.Enum, ", "))
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			fail("must be a boolean")
+		}
+	case "integer", "number":
+		num, ok := v.(json.Number)
+		if !ok {
+			fail("must be %s", map[string]string{"integer": "an integer", "number": "a number"}[s.Type])
+			return
+		}
+		f, err := num.Float64()
+		if err != nil {
+			fail("invalid number %s", num)
+			return
+		}
+		if s.Type == "integer" {
+			if _, err = num.Int64(); err != nil {
+				fail("must be an integer")
+				return
+			}
+		}
+		if s.Minimum != nil && f < *s.Minimum {
+			fail("must be >= %v", *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			fail("must be <= %v", *s.Maximum)
+		}
+	case "array":
+		list, ok := v.([]any)
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		for i, item := range list {
+			s.Items.validate(field+"["+strconv.Itoa(i)+"]", item, errs)
+		}
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		for _, name := range s.Required {
+			if obj[name] == nil {
+				*errs = append(*errs, FieldError{Field: join(field, name), Message: "is required"})
+			}
+		}
+		// 按字段名排序，保证错误顺序稳定
+		keys := make([]string, 0, len(obj))
+		for key := range obj {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if prop, ok := s.Properties[key]; ok {
+				prop.validate(join(field, key), obj[key], errs)
+			} else if s.AdditionalProperties != nil {
+				s.AdditionalProperties.validate(join(field, key), obj[key], errs)
+			}
+		}
+	}
+}
+
+// join 拼接字段路径
+func join(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
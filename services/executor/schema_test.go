@@ -0,0 +1,167 @@
+package executor
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type schemaTestParams struct {
+	Name  string            `json:"name" schema:"required,minLength=1"`
+	Mode  string            `json:"mode" schema:"enum=fast|slow"`
+	Count int               `json:"count" schema:"min=1,max=10"`
+	Ratio float64           `json:"ratio"`
+	Debug bool              `json:"debug"`
+	Tags  []string          `json:"tags"`
+	Env   map[string]string `json:"env"`
+}
+
+// decodeJSON 按参数校验时的方式解码，数字保留为 json.Number
+func decodeJSON(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		t.Fatalf("decode %s: %v", s, err)
+	}
+	return v
+}
+
+func TestSchemaValidate(t *testing.T) {
+	structSchema := SchemaOf(schemaTestParams{})
+	one := 1.0
+	tests := []struct {
+		name   string
+		schema *Schema
+		params string
+		want   []FieldError
+	}{
+		{
+			name:   "valid",
+			schema: structSchema,
+			params: `{"name":"a","mode":"fast","count":3,"ratio":0.5,"debug":true,"tags":["x"],"env":{"K":"V"}}`,
+		},
+		{
+			name:   "missing required",
+			schema: structSchema,
+			params: `{}`,
+			want:   []FieldError{{Field: "name", Message: "is required"}},
+		},
+		{
+			name:   "null is unset",
+			schema: structSchema,
+			params: `{"name":"a","mode":null}`,
+		},
+		{
+			name:   "empty string",
+			schema: structSchema,
+			params: `{"name":""}`,
+			want:   []FieldError{{Field: "name", Message: "must not be empty"}},
+		},
+		{
+			name:   "enum",
+			schema: structSchema,
+			params: `{"name":"a","mode":"medium"}`,
+			want:   []FieldError{{Field: "mode", Message: "must be one of fast, slow"}},
+		},
+		{
+			name:   "range",
+			schema: structSchema,
+			params: `{"name":"a","count":11}`,
+			want:   []FieldError{{Field: "count", Message: "must be <= 10"}},
+		},
+		{
+			name:   "integer",
+			schema: structSchema,
+			params: `{"name":"a","count":1.5}`,
+			want:   []FieldError{{Field: "count", Message: "must be an integer"}},
+		},
+		{
+			name:   "nested types sorted by field",
+			schema: structSchema,
+			params: `{"name":"a","tags":["x",1],"env":{"K":true},"debug":"yes"}`,
+			want: []FieldError{
+				{Field: "debug", Message: "must be a boolean"},
+				{Field: "env.K", Message: "must be a string"},
+				{Field: "tags[1]", Message: "must be a string"},
+			},
+		},
+		{
+			name:   "root type",
+			schema: structSchema,
+			params: `[1]`,
+			want:   []FieldError{{Message: "must be an object"}},
+		},
+		{
+			name:   "nil schema",
+			schema: nil,
+			params: `{"a":1}`,
+		},
+		{
+			name:   "nil items",
+			schema: &Schema{Type: "array"},
+			params: `[1,"a"]`,
+		},
+		{
+			name:   "nil property",
+			schema: &Schema{Type: "object", Properties: map[string]*Schema{"a": nil}},
+			params: `{"a":1}`,
+		},
+		{
+			name:   "additional properties",
+			schema: &Schema{Type: "object", AdditionalProperties: &Schema{Type: "number", Minimum: &one}},
+			params: `{"a":2,"b":0}`,
+			want:   []FieldError{{Field: "b", Message: "must be >= 1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.schema.Validate(decodeJSON(t, tt.params))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%s) = %v, want %v", tt.params, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaCheck(t *testing.T) {
+	negative, lo, hi := -1, 5.0, 1.0
+	tests := []struct {
+		name    string
+		schema  *Schema
+		wantErr string
+	}{
+		{name: "empty", schema: &Schema{}},
+		{name: "struct", schema: SchemaOf(schemaTestParams{})},
+		{name: "nil", schema: nil, wantErr: "schema is null"},
+		{name: "unknown type", schema: &Schema{Type: "date"}, wantErr: `unsupported type "date"`},
+		{name: "negative minLength", schema: &Schema{Type: "string", MinLength: &negative}, wantErr: "negative minLength"},
+		{name: "inverted range", schema: &Schema{Type: "number", Minimum: &lo, Maximum: &hi}, wantErr: "minimum is greater than maximum"},
+		{
+			name:    "nil property",
+			schema:  &Schema{Type: "object", Properties: map[string]*Schema{"a": nil}},
+			wantErr: "schema.properties.a is null",
+		},
+		{
+			name:    "nested items",
+			schema:  &Schema{Type: "array", Items: &Schema{Type: "tuple"}},
+			wantErr: "schema.items: unsupported type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schema.Check()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Check() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Check() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
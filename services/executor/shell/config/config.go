@@ -14,6 +14,67 @@ type ShellExecutorConfig struct {
 	Args    []string `yaml:"args"`
 }
 
+// OutputConfig 输出限制配置
+type OutputConfig struct {
+	// 单个任务最大转发字节数，0 表示不限制
+	MaxBytes uint64 `yaml:"maxBytes"`
+	// 单个任务最大转发行数，0 表示不限制
+	MaxLines uint64 `yaml:"maxLines"`
+	// 超出限制后的处理策略
+	OverflowPolicy string `yaml:"overflowPolicy"`
+	// truncate 策略下保留并在结束时发送的末尾行数
+	TailLines int `yaml:"tailLines"`
+}
+
+const (
+	// OverflowPolicyTruncate 停止转发，仅保留末尾若干行
+	OverflowPolicyTruncate = "truncate"
+	// OverflowPolicyKill 直接杀死任务
+	OverflowPolicyKill = "kill"
+)
+
+// HeartbeatConfig 心跳配置
+type HeartbeatConfig struct {
+	// 心跳间隔秒数，0 表示不发送心跳
+	IntervalSec int `yaml:"intervalSec"`
+}
+
+// MarkersConfig 输出标记协议配置
+type MarkersConfig struct {
+	// 是否解析 stdout 中的标记行
+	Enabled bool `yaml:"enabled"`
+	// 标记行前缀
+	Prefix string `yaml:"prefix"`
+}
+
+// WorkspaceConfig 任务临时工作目录配置
+type WorkspaceConfig struct {
+	// 是否为每次运行创建独立的临时工作目录
+	Enabled bool `yaml:"enabled"`
+	// 临时工作目录的根目录
+	RootDir string `yaml:"rootDir"`
+	// 任务失败时保留工作目录的分钟数，0 表示立即删除
+	KeepOnFailureMinutes int `yaml:"keepOnFailureMinutes"`
+	// 根目录磁盘占用上限字节数，超出时拒绝新任务，0 表示不限制
+	MaxTotalBytes uint64 `yaml:"maxTotalBytes"`
+}
+
+// ScriptConfig 脚本执行器配置
+type ScriptConfig struct {
+	// 允许的解释器名称与可执行文件路径
+	Interpreters map[string]string `yaml:"interpreters"`
+	// 脚本临时文件所在目录，为空时使用系统临时目录
+	TempDir string `yaml:"tempDir"`
+	// 脚本内容最大字节数
+	MaxScriptBytes int `yaml:"maxScriptBytes"`
+	// 是否要求脚本 sha256 必须在可信列表中
+	RequireTrustedHash bool `yaml:"requireTrustedHash"`
+	// 可信脚本的 sha256 列表
+	TrustedHashes []string `yaml:"trustedHashes"`
+	// 启用命令验证但未要求可信哈希时，是否仍允许执行未经验证的脚本
+	AllowUnvalidated bool `yaml:"allowUnvalidated"`
+}
+
 // SecurityConfig 安全配置
 type SecurityConfig struct {
 	EnableValidation bool `yaml:"enableValidation"`
@@ -38,8 +99,13 @@ type LoggingConfig struct {
 
 // Config Shell配置结构 - 统一的配置管理中心
 type Config struct {
-	Shell    ShellExecutorConfig `yaml:"shell"`
-	Security SecurityConfig      `yaml:"security"`
+	Shell     ShellExecutorConfig `yaml:"shell"`
+	Security  SecurityConfig      `yaml:"security"`
+	Output    OutputConfig        `yaml:"output"`
+	Heartbeat HeartbeatConfig     `yaml:"heartbeat"`
+	Markers   MarkersConfig       `yaml:"markers"`
+	Workspace WorkspaceConfig     `yaml:"workspace"`
+	Script    ScriptConfig        `yaml:"script"`
 }
 
 var (
@@ -59,6 +125,21 @@ func lazyLoadConfig() {
 			globalConfig.Shell.Command = "/bin/bash"
 			globalConfig.Shell.Args = []string{"-c"}
 		}
+		if globalConfig.Output.OverflowPolicy == "" {
+			globalConfig.Output.OverflowPolicy = OverflowPolicyTruncate
+		}
+		if globalConfig.Markers.Prefix == "" {
+			globalConfig.Markers.Prefix = "::goumang::"
+		}
+		if globalConfig.Workspace.RootDir == "" {
+			globalConfig.Workspace.RootDir = path.Join(env.RootPath(), "data", "workspaces")
+		}
+		if globalConfig.Script.MaxScriptBytes <= 0 {
+			globalConfig.Script.MaxScriptBytes = 1 << 20
+		}
+		if globalConfig.Output.TailLines < 0 {
+			globalConfig.Output.TailLines = 0
+		}
 	})
 
 	return
@@ -75,3 +156,33 @@ func GetSecurityConfig() SecurityConfig {
 	lazyLoadConfig()
 	return globalConfig.Security
 }
+
+// GetOutputConfig 获取输出限制配置
+func GetOutputConfig() OutputConfig {
+	lazyLoadConfig()
+	return globalConfig.Output
+}
+
+// GetHeartbeatConfig 获取心跳配置
+func GetHeartbeatConfig() HeartbeatConfig {
+	lazyLoadConfig()
+	return globalConfig.Heartbeat
+}
+
+// GetMarkersConfig 获取输出标记协议配置
+func GetMarkersConfig() MarkersConfig {
+	lazyLoadConfig()
+	return globalConfig.Markers
+}
+
+// GetWorkspaceConfig 获取任务临时工作目录配置
+func GetWorkspaceConfig() WorkspaceConfig {
+	lazyLoadConfig()
+	return globalConfig.Workspace
+}
+
+// GetScriptConfig 获取脚本执行器配置
+func GetScriptConfig() ScriptConfig {
+	lazyLoadConfig()
+	return globalConfig.Script
+}
@@ -3,15 +3,19 @@ package shell
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"goumang-worker/services/artifact"
 	"goumang-worker/services/executor"
 	"goumang-worker/services/executor/shell/config"
 	"goumang-worker/services/executor/shell/security"
 	"goumang-worker/services/pb"
 	"io"
+	"os"
 	"os/exec"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/bpcoder16/Chestnut/v2/core/gtask"
 	"github.com/bpcoder16/Chestnut/v2/logit"
@@ -21,11 +25,18 @@ import (
 
 // init 自动注册 Shell 执行器到默认工厂
 func init() {
-	executor.RegisterExecutor(pb.Method_SHELL, NewExecutor)
+	executor.RegisterExecutor(pb.Method_SHELL.String(), NewExecutor)
+	executor.RegisterParams(pb.Method_SHELL.String(), executor.ParamsSpec{
+		Version:     1,
+		Description: "Shell command run by the configured shell",
+		Params:      "",
+	})
 }
 
 const (
 	bufSize = 1000
+	// outputDrainDelay 命令退出后等待仍占用输出管道的后台子进程的时长
+	outputDrainDelay = 2 * time.Second
 )
 
 // Executor shell 命令执行器
@@ -48,7 +59,7 @@ func (e *Executor) getShellCommand(command string) (string, []string) {
 }
 
 // Execute 执行 shell 命令
-func (e *Executor) Execute(ctx context.Context, command string, stream pb.Task_RunServer) error {
+func (e *Executor) Execute(ctx context.Context, command string, sink executor.Sink) error {
 	command = strings.TrimSpace(command)
 	if len(command) == 0 {
 		return status.Error(codes.InvalidArgument, "empty command")
@@ -68,30 +79,55 @@ func (e *Executor) Execute(ctx context.Context, command string, stream pb.Task_R
 
 	// 获取配置化的 shell 命令和参数
 	shellCmd, shellArgs := e.getShellCommand(command)
-	cmd := exec.CommandContext(ctx, shellCmd, shellArgs...)
+	return e.run(ctx, shellCmd, shellArgs, sink)
+}
+
+// run 启动进程并转发输出，统一处理工作目录、输出限制、心跳与产物收集
+func (e *Executor) run(ctx context.Context, name string, args []string, sink executor.Sink) (err error) {
+	taskInfo := executor.TaskInfoFromContext(ctx)
+
+	// 按需为本次运行创建独立的临时工作目录，已指定共享工作目录时直接使用
+	var ws *workspace
+	if wsConfig := config.GetWorkspaceConfig(); wsConfig.Enabled && taskInfo.WorkDir == "" {
+		if ws, err = newWorkspace(ctx, wsConfig, taskInfo.RunTaskID); err != nil {
+			if errors.Is(err, errWorkspaceFull) {
+				return status.Error(codes.ResourceExhausted, err.Error())
+			}
+			return status.Error(codes.Internal, err.Error())
+		}
+		defer func() {
+			ws.cleanup(ctx, err != nil)
+		}()
+	}
+
+	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Setpgid: true, // 独立进程组，便于杀掉整个子进程组
 	}
-
-	// 获取 stdout 和 stderr
-	stdoutPipe, err := cmd.StdoutPipe()
-	if err != nil {
-		return status.Error(codes.Internal, fmt.Sprintf("failed to get stdout pipe: %v", err))
+	if ws != nil {
+		cmd.Dir = ws.dir
+		cmd.Env = append(os.Environ(), "TMPDIR="+ws.dir)
 	}
-	defer func() {
-		if errS := stdoutPipe.Close(); errS != nil && !strings.Contains(errS.Error(), "already closed") {
-			logit.Context(ctx).WarnW("stdoutPipe.Close().Err", errS)
+	if taskInfo.WorkDir != "" {
+		cmd.Dir = taskInfo.WorkDir
+	}
+	if len(taskInfo.Env) > 0 {
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
 		}
-	}()
-
-	stderrPipe, err := cmd.StderrPipe()
-	if err != nil {
-		return status.Error(codes.Internal, fmt.Sprintf("failed to get stderr pipe: %v", err))
+		cmd.Env = append(cmd.Env, taskInfo.Env...)
 	}
+
+	// stdout 和 stderr 经由 exec 的复制协程写入管道，cmd.Wait 等待复制结束，
+	// 继承了输出的后台子进程最多再占用 outputDrainDelay，之后管道被关闭
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+	cmd.Stdout = stdoutWriter
+	cmd.Stderr = stderrWriter
+	cmd.WaitDelay = outputDrainDelay
 	defer func() {
-		if errS := stderrPipe.Close(); errS != nil && !strings.Contains(errS.Error(), "already closed") {
-			logit.Context(ctx).WarnW("stderrPipe.Close().Err", errS)
-		}
+		_ = stdoutReader.Close()
+		_ = stderrReader.Close()
 	}()
 
 	// 启动命令
@@ -107,7 +143,12 @@ func (e *Executor) Execute(ctx context.Context, command string, stream pb.Task_R
 
 	// 读取 stdout
 	g.Go(func() error {
-		scanner := bufio.NewScanner(stdoutPipe)
+		defer close(stdoutCh)
+		// 停止读取后关闭读端，避免复制协程阻塞
+		defer func() {
+			_ = stdoutReader.Close()
+		}()
+		scanner := bufio.NewScanner(stdoutReader)
 		for scanner.Scan() {
 			select {
 			case stdoutCh <- scanner.Text():
@@ -119,13 +160,17 @@ func (e *Executor) Execute(ctx context.Context, command string, stream pb.Task_R
 			logit.Context(ctx).WarnW("stdout.scanner.Err", errS)
 			stdoutCh <- fmt.Sprintf("stdout read error: %v", errS)
 		}
-		close(stdoutCh)
 		return nil
 	})
 
 	// 读取 stderr
 	g.Go(func() error {
-		scanner := bufio.NewScanner(stderrPipe)
+		defer close(stderrCh)
+		// 停止读取后关闭读端，避免复制协程阻塞
+		defer func() {
+			_ = stderrReader.Close()
+		}()
+		scanner := bufio.NewScanner(stderrReader)
 		for scanner.Scan() {
 			select {
 			case stderrCh <- scanner.Text():
@@ -137,35 +182,66 @@ func (e *Executor) Execute(ctx context.Context, command string, stream pb.Task_R
 			logit.Context(ctx).WarnW("stderr.scanner.Err", errS)
 			stderrCh <- fmt.Sprintf("stderr read error: %v", errS)
 		}
-		close(stderrCh)
 		return nil
 	})
 
 	// 发送流
+	limiter := newOutputLimiter(config.GetOutputConfig())
+	markers := newMarkerParser(config.GetMarkersConfig())
+	sendFailed := false
 	sendErrCh := make(chan error, 1)
 	g.Go(func() error {
+		// 定时心跳，避免长时间无输出的任务被视为僵死
+		var heartbeatCh <-chan time.Time
+		heartbeat := newHeartbeater(cmd.Process.Pid)
+		if interval := config.GetHeartbeatConfig().IntervalSec; interval > 0 {
+			ticker := time.NewTicker(time.Duration(interval) * time.Second)
+			defer ticker.Stop()
+			heartbeatCh = ticker.C
+		}
+
 		for stdoutCh != nil || stderrCh != nil {
+			var line outputLine
 			select {
-			case line, ok := <-stdoutCh:
+			case <-heartbeatCh:
+				if errS := sink.Heartbeat(heartbeat.next(gCtx)); errS != nil {
+					logit.Context(gCtx).WarnW("heartbeat.stream.Send.Err", errS)
+					sendFailed = true
+					sendErrCh <- errS
+					return nil
+				}
+				continue
+			case text, ok := <-stdoutCh:
 				if !ok {
 					stdoutCh = nil
 					continue
 				}
-				if errS := stream.Send(&pb.TaskResponse{Content: &pb.TaskResponse_Output{Output: line}}); errS != nil {
-					logit.Context(gCtx).WarnW("stdout.stream.Send.Err", errS)
-					sendErrCh <- errS
-					return nil
+				line = outputLine{text: text}
+				// 标记行转换为事件，同样计入输出限制
+				if event, isMarker := markers.parse(text); isMarker {
+					line.event = event
 				}
-			case line, ok := <-stderrCh:
+			case text, ok := <-stderrCh:
 				if !ok {
 					stderrCh = nil
 					continue
 				}
-				if errS := stream.Send(&pb.TaskResponse{Content: &pb.TaskResponse_Error{Error: line}}); errS != nil {
-					logit.Context(gCtx).WarnW("stderr.stream.Send.Err", errS)
-					sendErrCh <- errS
-					return nil
-				}
+				line = outputLine{text: text, isErr: true}
+			}
+
+			forward, exceeded := limiter.accept(line)
+			if exceeded && limiter.cfg.OverflowPolicy == config.OverflowPolicyKill {
+				sendErrCh <- errOutputLimitExceeded
+				return nil
+			}
+			if !forward {
+				continue
+			}
+			if errS := line.emit(sink); errS != nil {
+				logit.Context(gCtx).WarnW("stream.Send.Err", errS)
+				sendFailed = true
+				sendErrCh <- errS
+				return nil
 			}
 		}
 		sendErrCh <- nil
@@ -180,6 +256,12 @@ func (e *Executor) Execute(ctx context.Context, command string, stream pb.Task_R
 			}
 			return status.Error(codes.Internal, fmt.Sprintf("command canceled or timeout: %v", gCtx.Err()))
 		case errS := <-sendErrCh:
+			if errors.Is(errS, errOutputLimitExceeded) {
+				if errK := e.killProcessGroup(ctx, cmd); errK != nil {
+					logit.Context(ctx).WarnW("killProcessGroup.Err", errK)
+				}
+				return status.Error(codes.ResourceExhausted, fmt.Sprintf("task killed: %v", errS))
+			}
 			if errS != nil {
 				if errK := e.killProcessGroup(ctx, cmd); errK != nil {
 					logit.Context(ctx).WarnW("killProcessGroup.Err", errK)
@@ -190,16 +272,71 @@ func (e *Executor) Execute(ctx context.Context, command string, stream pb.Task_R
 		return nil
 	})
 
+	// 运行期间定期检查工作目录根的磁盘占用，超限时终止任务
+	exited := make(chan struct{})
+	if ws != nil && ws.cfg.MaxTotalBytes > 0 {
+		g.Go(func() error {
+			return ws.watch(gCtx, exited)
+		})
+	}
+
 	g.Go(func() error {
-		// 等待命令退出
-		if errC := cmd.Wait(); errC != nil {
+		errC := cmd.Wait()
+		close(exited)
+		// 关闭写端，读取协程读完剩余输出后结束
+		_ = stdoutWriter.Close()
+		_ = stderrWriter.Close()
+		// 命令本身成功、只是后台子进程仍占用输出时视为成功
+		if errC != nil && !errors.Is(errC, exec.ErrWaitDelay) {
 			logit.Context(ctx).WarnW("cmd.Wait.Err", errC)
+			var exitErr *exec.ExitError
+			if errors.As(errC, &exitErr) && exitErr.ExitCode() >= 0 {
+				return executor.ExitStatusError(exitErr.ExitCode(), fmt.Sprintf("command exited with error: %v", errC))
+			}
 			return status.Error(codes.Internal, fmt.Sprintf("command exited with error: %v", errC))
 		}
 		return nil
 	})
 
-	return g.Wait()
+	err = g.Wait()
+
+	// 收集任务产物，需在清理工作目录之前完成
+	result := limiter.result()
+	result.Artifacts = artifact.Collect(ctx, taskInfo.RunTaskID, cmd.Dir, taskInfo.Artifacts)
+	if ws != nil {
+		result.WorkspaceBytes = ws.size()
+	}
+
+	if !sendFailed {
+		e.sendSummary(ctx, sink, limiter, result)
+	}
+	return err
+}
+
+// sendSummary 发送截断后保留的末尾行以及最终结果
+func (e *Executor) sendSummary(ctx context.Context, sink executor.Sink, limiter *outputLimiter, result *pb.TaskResult) {
+	if limiter.truncated && limiter.cfg.OverflowPolicy == config.OverflowPolicyTruncate {
+		tail := limiter.tailLines()
+		omitted := limiter.totalLines - limiter.forwardedLines - uint64(len(tail))
+		notice := fmt.Sprintf("output truncated: %d lines omitted, showing last %d lines", omitted, len(tail))
+		if errS := sink.Stderr(notice); errS != nil {
+			logit.Context(ctx).WarnW("truncated.stream.Send.Err", errS)
+			return
+		}
+		for _, line := range tail {
+			if errS := line.emit(sink); errS != nil {
+				logit.Context(ctx).WarnW("tail.stream.Send.Err", errS)
+				return
+			}
+		}
+		limiter.forwardTail(tail)
+		result.ForwardedBytes = limiter.forwardedBytes
+		result.ForwardedLines = limiter.forwardedLines
+	}
+
+	if errS := sink.Result(result); errS != nil {
+		logit.Context(ctx).WarnW("result.stream.Send.Err", errS)
+	}
 }
 
 // killProcessGroup 杀死进程组
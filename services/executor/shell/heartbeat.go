@@ -0,0 +1,60 @@
+package shell
+
+import (
+	"context"
+	"goumang-worker/services/pb"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+)
+
+// processGroupStats 进程组资源占用
+type processGroupStats struct {
+	processCount int
+	cpuSeconds   float64
+	rssBytes     uint64
+}
+
+// heartbeater 生成任务运行期间的心跳消息
+type heartbeater struct {
+	pgid      int
+	startTime time.Time
+	lastTime  time.Time
+	lastCPU   float64
+}
+
+// newHeartbeater 创建心跳生成器
+func newHeartbeater(pgid int) *heartbeater {
+	now := time.Now()
+	return &heartbeater{
+		pgid:      pgid,
+		startTime: now,
+		lastTime:  now,
+	}
+}
+
+// next 生成下一条心跳，CPU 占用率按距上一次心跳的间隔计算
+func (h *heartbeater) next(ctx context.Context) *pb.Heartbeat {
+	now := time.Now()
+	heartbeat := &pb.Heartbeat{
+		ElapsedMs: now.Sub(h.startTime).Milliseconds(),
+	}
+
+	stats, err := readProcessGroupStats(h.pgid)
+	if err != nil {
+		logit.Context(ctx).WarnW("readProcessGroupStats.Err", err)
+		return heartbeat
+	}
+
+	heartbeat.Alive = stats.processCount > 0
+	heartbeat.ProcessCount = int32(stats.processCount)
+	heartbeat.CpuSeconds = stats.cpuSeconds
+	heartbeat.RssBytes = stats.rssBytes
+	if interval := now.Sub(h.lastTime).Seconds(); interval > 0 && stats.cpuSeconds >= h.lastCPU {
+		heartbeat.CpuPercent = (stats.cpuSeconds - h.lastCPU) / interval * 100
+	}
+
+	h.lastTime = now
+	h.lastCPU = stats.cpuSeconds
+	return heartbeat
+}
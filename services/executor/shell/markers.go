@@ -0,0 +1,66 @@
+package shell
+
+import (
+	"goumang-worker/services/executor/shell/config"
+	"goumang-worker/services/pb"
+	"strconv"
+	"strings"
+)
+
+const (
+	markerProgress = "progress"
+	markerMetric   = "metric"
+	markerStatus   = "status"
+)
+
+// markerParser 解析 stdout 中的标记行，例如 "::goumang::progress 42"
+type markerParser struct {
+	prefix string
+}
+
+// newMarkerParser 根据配置创建标记解析器，未启用时返回 nil
+func newMarkerParser(cfg config.MarkersConfig) *markerParser {
+	if !cfg.Enabled || cfg.Prefix == "" {
+		return nil
+	}
+	return &markerParser{prefix: cfg.Prefix}
+}
+
+// parse 将标记行转换为事件，非标记行或格式错误时返回 false，按普通输出处理
+func (p *markerParser) parse(line string) (*pb.TaskEvent, bool) {
+	if p == nil || !strings.HasPrefix(line, p.prefix) {
+		return nil, false
+	}
+
+	kind, value, _ := strings.Cut(strings.TrimPrefix(line, p.prefix), " ")
+	value = strings.TrimSpace(value)
+
+	switch kind {
+	case markerProgress:
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil || percent < 0 || percent > 100 {
+			return nil, false
+		}
+		return &pb.TaskEvent{Event: &pb.TaskEvent_Progress{Progress: percent}}, true
+	case markerMetric:
+		values := make(map[string]string)
+		for _, pair := range strings.Fields(value) {
+			key, val, ok := strings.Cut(pair, "=")
+			if !ok || key == "" {
+				return nil, false
+			}
+			values[key] = val
+		}
+		if len(values) == 0 {
+			return nil, false
+		}
+		return &pb.TaskEvent{Event: &pb.TaskEvent_Metrics{Metrics: &pb.Metrics{Values: values}}}, true
+	case markerStatus:
+		if value == "" {
+			return nil, false
+		}
+		return &pb.TaskEvent{Event: &pb.TaskEvent_Status{Status: value}}, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,119 @@
+package shell
+
+import (
+	"errors"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/shell/config"
+	"goumang-worker/services/pb"
+)
+
+// errOutputLimitExceeded 输出超出限制且策略为 kill
+var errOutputLimitExceeded = errors.New("output limit exceeded")
+
+// outputLine 一行输出
+type outputLine struct {
+	text  string
+	isErr bool
+	// 标记行解析出的事件，非空时以事件而非输出转发
+	event *pb.TaskEvent
+}
+
+// emit 输出到 sink
+func (l outputLine) emit(sink executor.Sink) error {
+	if l.event != nil {
+		return sink.Event(l.event)
+	}
+	if l.isErr {
+		return sink.Stderr(l.text)
+	}
+	return sink.Stdout(l.text)
+}
+
+// outputLimiter 输出限制器，统计输出量并在超限后保留末尾若干行
+type outputLimiter struct {
+	cfg config.OutputConfig
+
+	totalBytes     uint64
+	forwardedBytes uint64
+	totalLines     uint64
+	forwardedLines uint64
+	truncated      bool
+
+	// 环形缓冲区，保存超限后的末尾行
+	tail     []outputLine
+	tailNext int
+	tailFull bool
+}
+
+// newOutputLimiter 创建输出限制器
+func newOutputLimiter(cfg config.OutputConfig) *outputLimiter {
+	l := &outputLimiter{cfg: cfg}
+	if cfg.OverflowPolicy == config.OverflowPolicyTruncate && cfg.TailLines > 0 {
+		l.tail = make([]outputLine, cfg.TailLines)
+	}
+	return l
+}
+
+// accept 记录一行输出，返回该行是否应转发；超出限制时 exceeded 为 true（仅首次）
+func (l *outputLimiter) accept(line outputLine) (forward bool, exceeded bool) {
+	size := uint64(len(line.text)) + 1 // 计入换行符
+	l.totalBytes += size
+	l.totalLines++
+
+	if l.truncated {
+		l.keep(line)
+		return false, false
+	}
+
+	if (l.cfg.MaxBytes > 0 && l.forwardedBytes+size > l.cfg.MaxBytes) ||
+		(l.cfg.MaxLines > 0 && l.forwardedLines+1 > l.cfg.MaxLines) {
+		l.truncated = true
+		l.keep(line)
+		return false, true
+	}
+
+	l.forwardedBytes += size
+	l.forwardedLines++
+	return true, false
+}
+
+// keep 写入环形缓冲区
+func (l *outputLimiter) keep(line outputLine) {
+	if len(l.tail) == 0 {
+		return
+	}
+	l.tail[l.tailNext] = line
+	l.tailNext = (l.tailNext + 1) % len(l.tail)
+	if l.tailNext == 0 {
+		l.tailFull = true
+	}
+}
+
+// tailLines 按原始顺序返回保留的末尾行
+func (l *outputLimiter) tailLines() []outputLine {
+	if !l.tailFull {
+		return l.tail[:l.tailNext]
+	}
+	lines := make([]outputLine, 0, len(l.tail))
+	lines = append(lines, l.tail[l.tailNext:]...)
+	return append(lines, l.tail[:l.tailNext]...)
+}
+
+// forwardTail 记录末尾行已被转发
+func (l *outputLimiter) forwardTail(lines []outputLine) {
+	for _, line := range lines {
+		l.forwardedBytes += uint64(len(line.text)) + 1
+		l.forwardedLines++
+	}
+}
+
+// result 生成任务最终结果
+func (l *outputLimiter) result() *pb.TaskResult {
+	return &pb.TaskResult{
+		TotalBytes:     l.totalBytes,
+		ForwardedBytes: l.forwardedBytes,
+		TotalLines:     l.totalLines,
+		ForwardedLines: l.forwardedLines,
+		Truncated:      l.truncated,
+	}
+}
@@ -0,0 +1,95 @@
+package shell
+
+import (
+	"goumang-worker/services/executor/shell/config"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestOutputLimiter(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.OutputConfig
+		lines         int
+		wantForwarded int
+		wantTail      []string
+	}{
+		{
+			name:          "unlimited",
+			cfg:           config.OutputConfig{OverflowPolicy: config.OverflowPolicyTruncate, TailLines: 2},
+			lines:         5,
+			wantForwarded: 5,
+		},
+		{
+			name:          "tail not full",
+			cfg:           config.OutputConfig{MaxLines: 3, OverflowPolicy: config.OverflowPolicyTruncate, TailLines: 5},
+			lines:         5,
+			wantForwarded: 3,
+			wantTail:      []string{"3", "4"},
+		},
+		{
+			name:          "tail exactly full",
+			cfg:           config.OutputConfig{MaxLines: 2, OverflowPolicy: config.OverflowPolicyTruncate, TailLines: 3},
+			lines:         5,
+			wantForwarded: 2,
+			wantTail:      []string{"2", "3", "4"},
+		},
+		{
+			name:          "tail wrapped",
+			cfg:           config.OutputConfig{MaxLines: 2, OverflowPolicy: config.OverflowPolicyTruncate, TailLines: 3},
+			lines:         10,
+			wantForwarded: 2,
+			wantTail:      []string{"7", "8", "9"},
+		},
+		{
+			// 每行 2 字节（含换行符）
+			name:          "byte limit",
+			cfg:           config.OutputConfig{MaxBytes: 7, OverflowPolicy: config.OverflowPolicyTruncate, TailLines: 1},
+			lines:         6,
+			wantForwarded: 3,
+			wantTail:      []string{"5"},
+		},
+		{
+			name:          "kill keeps no tail",
+			cfg:           config.OutputConfig{MaxLines: 2, OverflowPolicy: config.OverflowPolicyKill, TailLines: 3},
+			lines:         5,
+			wantForwarded: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newOutputLimiter(tt.cfg)
+			forwarded, exceeded := 0, 0
+			for i := 0; i < tt.lines; i++ {
+				forward, exc := l.accept(outputLine{text: strconv.Itoa(i)})
+				if forward {
+					forwarded++
+				}
+				if exc {
+					exceeded++
+				}
+			}
+			if forwarded != tt.wantForwarded {
+				t.Errorf("forwarded %d lines, want %d", forwarded, tt.wantForwarded)
+			}
+			if wantExceeded := min(1, tt.lines-tt.wantForwarded); exceeded != wantExceeded {
+				t.Errorf("exceeded reported %d times, want %d", exceeded, wantExceeded)
+			}
+
+			var tail []string
+			for _, line := range l.tailLines() {
+				tail = append(tail, line.text)
+			}
+			if !slices.Equal(tail, tt.wantTail) {
+				t.Errorf("tail = %v, want %v", tail, tt.wantTail)
+			}
+
+			result := l.result()
+			if result.TotalLines != uint64(tt.lines) || result.ForwardedLines != uint64(tt.wantForwarded) ||
+				result.Truncated != (tt.lines > tt.wantForwarded) {
+				t.Errorf("result = %v", result)
+			}
+		})
+	}
+}
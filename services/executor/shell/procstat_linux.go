@@ -0,0 +1,57 @@
+package shell
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"strconv"
+)
+
+// clockTicks /proc 中 CPU 时间的单位（USER_HZ），Linux 上固定为 100
+const clockTicks = 100
+
+// readProcessGroupStats 从 /proc 汇总进程组内所有进程的资源占用
+func readProcessGroupStats(pgid int) (processGroupStats, error) {
+	var stats processGroupStats
+
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return stats, err
+	}
+
+	pageSize := uint64(os.Getpagesize())
+	for _, entry := range entries {
+		if _, errA := strconv.Atoi(entry.Name()); errA != nil {
+			continue
+		}
+		data, errR := os.ReadFile(path.Join("/proc", entry.Name(), "stat"))
+		if errR != nil {
+			// 进程可能已退出
+			continue
+		}
+
+		// comm 字段可能包含空格，从最后一个 ')' 之后开始解析
+		idx := bytes.LastIndexByte(data, ')')
+		if idx < 0 {
+			continue
+		}
+		fields := bytes.Fields(data[idx+1:])
+		// fields[0] 对应 stat 第 3 个字段 state
+		if len(fields) < 22 {
+			continue
+		}
+		if pgrp, _ := strconv.Atoi(string(fields[2])); pgrp != pgid {
+			continue
+		}
+
+		utime, _ := strconv.ParseUint(string(fields[11]), 10, 64)
+		stime, _ := strconv.ParseUint(string(fields[12]), 10, 64)
+		rss, _ := strconv.ParseUint(string(fields[21]), 10, 64)
+
+		stats.processCount++
+		stats.cpuSeconds += float64(utime+stime) / clockTicks
+		stats.rssBytes += rss * pageSize
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package shell
+
+import "errors"
+
+// readProcessGroupStats 非 Linux 平台不支持进程组资源统计
+func readProcessGroupStats(int) (processGroupStats, error) {
+	return processGroupStats{}, errors.New("process group stats not supported on this platform")
+}
@@ -0,0 +1,122 @@
+package shell
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/shell/config"
+	"goumang-worker/services/pb"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// init 自动注册脚本执行器到默认工厂
+func init() {
+	executor.RegisterExecutor(pb.Method_SCRIPT.String(), NewScriptExecutor)
+	executor.RegisterParams(pb.Method_SCRIPT.String(), executor.ParamsSpec{
+		Version:     1,
+		Description: "Multi-line script run by a whitelisted interpreter",
+		Params:      ScriptParams{},
+	})
+}
+
+// ScriptParams 脚本执行参数（method_params 的 JSON 内容）
+type ScriptParams struct {
+	// 脚本内容
+	Script string `json:"script" schema:"required,minLength=1"`
+	// 解释器名称，需在配置的白名单中
+	Interpreter string `json:"interpreter" schema:"required,minLength=1"`
+	// 传给脚本的参数
+	Args []string `json:"args"`
+	// 期望的脚本 sha256，非空时必须匹配
+	Sha256 string `json:"sha256"`
+}
+
+// ScriptExecutor 多行脚本执行器，脚本写入私有临时文件后由解释器执行
+type ScriptExecutor struct {
+	Executor
+}
+
+// NewScriptExecutor 创建新的脚本执行器
+func NewScriptExecutor() executor.Executor {
+	return &ScriptExecutor{}
+}
+
+// Execute 执行脚本
+func (e *ScriptExecutor) Execute(ctx context.Context, params string, sink executor.Sink) error {
+	scriptConfig := config.GetScriptConfig()
+
+	// 脚本不经过命令验证，启用验证时必须以可信哈希约束或由运维显式放开
+	if config.GetSecurityConfig().EnableValidation && !scriptConfig.RequireTrustedHash && !scriptConfig.AllowUnvalidated {
+		logit.Context(ctx).WarnW("logType", "script denied", "reason", "unvalidated scripts are not allowed")
+		return status.Error(codes.PermissionDenied, "scripts bypass command validation: enable requireTrustedHash or allowUnvalidated")
+	}
+
+	p, err := executor.DecodeParams[ScriptParams](ctx, params)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid params: %v", err))
+	}
+	if strings.TrimSpace(p.Script) == "" {
+		return status.Error(codes.InvalidArgument, "empty script")
+	}
+	if len(p.Script) > scriptConfig.MaxScriptBytes {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("script exceeds %d bytes", scriptConfig.MaxScriptBytes))
+	}
+
+	interpreter, ok := scriptConfig.Interpreters[p.Interpreter]
+	if !ok || interpreter == "" {
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("interpreter not allowed: %q", p.Interpreter))
+	}
+
+	// 校验脚本哈希
+	sum := sha256.Sum256([]byte(p.Script))
+	hash := hex.EncodeToString(sum[:])
+	if p.Sha256 != "" && !strings.EqualFold(p.Sha256, hash) {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("script sha256 mismatch: expected %s, got %s", p.Sha256, hash))
+	}
+	// 配置中的哈希可能为大写十六进制，按不区分大小写比较
+	trusted := slices.ContainsFunc(scriptConfig.TrustedHashes, func(h string) bool {
+		return strings.EqualFold(strings.TrimSpace(h), hash)
+	})
+	if scriptConfig.RequireTrustedHash && !trusted {
+		logit.Context(ctx).WarnW("logType", "script denied", "reason", "untrusted script", "sha256", hash)
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("script not trusted: sha256 %s", hash))
+	}
+
+	scriptPath, cleanup, err := e.writeScript(scriptConfig.TempDir, p.Script)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("write script failed: %v", err))
+	}
+	defer cleanup(ctx)
+
+	return e.run(ctx, interpreter, append([]string{scriptPath}, p.Args...), sink)
+}
+
+// writeScript 将脚本写入仅当前用户可访问的临时目录，返回路径及清理函数
+func (e *ScriptExecutor) writeScript(tempDir, script string) (string, func(context.Context), error) {
+	// os.MkdirTemp 创建的目录权限为 0700
+	dir, err := os.MkdirTemp(tempDir, "goumang-script-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func(ctx context.Context) {
+		if errR := os.RemoveAll(dir); errR != nil {
+			logit.Context(ctx).WarnW("script.RemoveAll.Err", errR)
+		}
+	}
+
+	scriptPath := filepath.Join(dir, "script")
+	if err = os.WriteFile(scriptPath, []byte(script), 0o700); err != nil {
+		cleanup(context.Background())
+		return "", nil, err
+	}
+	return scriptPath, cleanup, nil
+}
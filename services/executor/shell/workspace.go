@@ -0,0 +1,136 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor/shell/config"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errWorkspaceFull 临时工作目录根目录磁盘占用超限
+var errWorkspaceFull = errors.New("workspace root disk usage exceeds limit")
+
+// watchInterval 运行期间检查磁盘占用的间隔
+const watchInterval = 2 * time.Second
+
+// workspace 单次运行的临时工作目录
+type workspace struct {
+	cfg config.WorkspaceConfig
+	dir string
+}
+
+// newWorkspace 在根目录下为本次运行创建独立的临时目录
+func newWorkspace(ctx context.Context, cfg config.WorkspaceConfig, runTaskID uint64) (*workspace, error) {
+	if err := os.MkdirAll(cfg.RootDir, 0o750); err != nil {
+		return nil, fmt.Errorf("create workspace root failed: %w", err)
+	}
+
+	// 清理上次进程退出前未来得及删除的保留目录
+	sweepWorkspaces(ctx, cfg)
+
+	if cfg.MaxTotalBytes > 0 {
+		used := dirSize(cfg.RootDir)
+		logit.Context(ctx).InfoW("workspace.root.usedBytes", used)
+		if used >= cfg.MaxTotalBytes {
+			return nil, fmt.Errorf("%w: used %d bytes, limit %d bytes", errWorkspaceFull, used, cfg.MaxTotalBytes)
+		}
+	}
+
+	dir, err := os.MkdirTemp(cfg.RootDir, strconv.FormatUint(runTaskID, 10)+"-")
+	if err != nil {
+		return nil, fmt.Errorf("create workspace failed: %w", err)
+	}
+	return &workspace{cfg: cfg, dir: dir}, nil
+}
+
+// size 返回工作目录当前占用的字节数
+func (w *workspace) size() uint64 {
+	return dirSize(w.dir)
+}
+
+// watch 定期检查根目录磁盘占用，超限时返回 ResourceExhausted，进程退出或 ctx 结束时返回 nil
+func (w *workspace) watch(ctx context.Context, exited <-chan struct{}) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-exited:
+			return nil
+		case <-ticker.C:
+			if used := dirSize(w.cfg.RootDir); used > w.cfg.MaxTotalBytes {
+				logit.Context(ctx).WarnW("logType", "workspace full", "dir", w.dir, "usedBytes", used, "maxTotalBytes", w.cfg.MaxTotalBytes)
+				return status.Error(codes.ResourceExhausted, fmt.Sprintf("task killed: %v: used %d bytes, limit %d bytes", errWorkspaceFull, used, w.cfg.MaxTotalBytes))
+			}
+		}
+	}
+}
+
+// cleanup 删除工作目录，失败的任务按配置延迟删除以便排查
+func (w *workspace) cleanup(ctx context.Context, failed bool) {
+	if failed && w.cfg.KeepOnFailureMinutes > 0 {
+		keep := time.Duration(w.cfg.KeepOnFailureMinutes) * time.Minute
+		logit.Context(ctx).InfoW("workspace.keepOnFailure", w.dir, "minutes", w.cfg.KeepOnFailureMinutes)
+		time.AfterFunc(keep, func() {
+			if err := os.RemoveAll(w.dir); err != nil {
+				logit.Context(ctx).WarnW("workspace.RemoveAll.Err", err)
+			}
+		})
+		return
+	}
+
+	if err := os.RemoveAll(w.dir); err != nil {
+		logit.Context(ctx).WarnW("workspace.RemoveAll.Err", err)
+	}
+}
+
+// sweepWorkspaces 删除根目录下超过保留时长的目录
+func sweepWorkspaces(ctx context.Context, cfg config.WorkspaceConfig) {
+	entries, err := os.ReadDir(cfg.RootDir)
+	if err != nil {
+		return
+	}
+
+	// 正在运行的任务目录不会被删除：保留时长至少覆盖最大任务超时
+	keep := time.Duration(cfg.KeepOnFailureMinutes) * time.Minute
+	if keep < 24*time.Hour {
+		keep = 24 * time.Hour
+	}
+	expireBefore := time.Now().Add(-keep)
+	for _, entry := range entries {
+		info, errI := entry.Info()
+		if errI != nil || !entry.IsDir() || info.ModTime().After(expireBefore) {
+			continue
+		}
+		if errR := os.RemoveAll(filepath.Join(cfg.RootDir, entry.Name())); errR != nil {
+			logit.Context(ctx).WarnW("workspace.sweep.Err", errR)
+		}
+	}
+}
+
+// dirSize 统计目录下所有普通文件的字节数
+func dirSize(dir string) uint64 {
+	var total uint64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if info, errI := d.Info(); errI == nil {
+				total += uint64(info.Size())
+			}
+		}
+		return nil
+	})
+	return total
+}
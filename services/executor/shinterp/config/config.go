@@ -0,0 +1,60 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// InterpExecutorConfig 内置解释器执行器配置
+type InterpExecutorConfig struct {
+	// 允许执行的外部命令名（内置命令如 echo、cd 不受限制）
+	AllowedCommands []string `yaml:"allowedCommands"`
+	// 查找外部命令使用的 PATH
+	Path string `yaml:"path"`
+	// 允许读取的路径
+	ReadPaths []string `yaml:"readPaths"`
+	// 允许读写的路径
+	WritePaths []string `yaml:"writePaths"`
+	// 是否校验外部命令参数中的路径
+	CheckArgPaths bool `yaml:"checkArgPaths"`
+	// 从 worker 环境继承的变量名，其余变量不传入脚本
+	PassEnv []string `yaml:"passEnv"`
+	// 取消时等待外部命令退出的秒数，超时后强制杀死
+	KillTimeoutSec int `yaml:"killTimeoutSec"`
+}
+
+// Config 内置解释器配置结构
+type Config struct {
+	Interp InterpExecutorConfig `yaml:"interp"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "interp.yaml"), &globalConfig); err != nil {
+			panic("loadConfig interp.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if globalConfig.Interp.Path == "" {
+			globalConfig.Interp.Path = "/usr/local/bin:/usr/bin:/bin"
+		}
+		if globalConfig.Interp.KillTimeoutSec <= 0 {
+			globalConfig.Interp.KillTimeoutSec = 2
+		}
+	})
+}
+
+// GetInterpConfig 获取内置解释器执行器配置
+func GetInterpConfig() InterpExecutorConfig {
+	lazyLoadConfig()
+	return globalConfig.Interp
+}
@@ -0,0 +1,169 @@
+package shinterp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/shinterp/config"
+	"goumang-worker/services/pb"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"mvdan.cc/sh/v3/expand"
+	"mvdan.cc/sh/v3/interp"
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// init 自动注册内置解释器执行器到默认工厂
+func init() {
+	executor.RegisterExecutor(pb.Method_SH_INTERP.String(), NewExecutor)
+	executor.RegisterParams(pb.Method_SH_INTERP.String(), executor.ParamsSpec{
+		Version:     1,
+		Description: "POSIX shell script run by the in-process interpreter",
+		Params:      "",
+	})
+}
+
+// Executor 基于 mvdan.cc/sh/interp 的进程内 POSIX 解释器执行器
+type Executor struct{}
+
+// NewExecutor 创建新的内置解释器执行器
+func NewExecutor() executor.Executor {
+	return &Executor{}
+}
+
+// Execute 在进程内解释执行脚本，每次外部命令调用和文件打开都按策略校验
+func (e *Executor) Execute(ctx context.Context, script string, sink executor.Sink) error {
+	script = strings.TrimSpace(script)
+	if len(script) == 0 {
+		return status.Error(codes.InvalidArgument, "empty script")
+	}
+
+	prog, err := syntax.NewParser().Parse(strings.NewReader(script), "")
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("script parsing failed: %v", err))
+	}
+
+	cfg := config.GetInterpConfig()
+	pol := &policy{cfg: cfg}
+	sender := executor.NewOutputSender(sink)
+	stdout := executor.NewLineWriter(sender, false)
+	stderr := executor.NewLineWriter(sender, true)
+
+	taskInfo := executor.TaskInfoFromContext(ctx)
+	environ := append(passEnv(cfg.PassEnv), taskInfo.Env...)
+	options := []interp.RunnerOption{
+		interp.StdIO(nil, stdout, stderr),
+		interp.Env(expand.ListEnviron(append(environ, "PATH="+cfg.Path)...)),
+		interp.ExecHandlers(e.execMiddleware(ctx, pol)),
+		interp.OpenHandler(e.openHandler(pol)),
+		interp.ReadDirHandler2(e.readDirHandler(pol)),
+		interp.StatHandler(e.statHandler(pol)),
+	}
+	if taskInfo.WorkDir != "" {
+		options = append(options, interp.Dir(taskInfo.WorkDir))
+	}
+	runner, err := interp.New(options...)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("create interpreter failed: %v", err))
+	}
+
+	runErr := runner.Run(ctx, prog)
+	stdout.Flush()
+	stderr.Flush()
+
+	if errS := sender.Err(); errS != nil {
+		logit.Context(ctx).WarnW("stream.Send.Err", errS)
+		return status.Error(codes.Internal, fmt.Sprintf("failed to send output: %v", errS))
+	}
+	if errS := sink.Result(sender.Result()); errS != nil {
+		logit.Context(ctx).WarnW("result.stream.Send.Err", errS)
+	}
+
+	switch {
+	case runErr == nil:
+		return nil
+	case errors.Is(runErr, errDenied):
+		return status.Error(codes.PermissionDenied, runErr.Error())
+	case ctx.Err() != nil:
+		return status.Error(codes.Internal, fmt.Sprintf("script canceled or timeout: %v", ctx.Err()))
+	}
+	if code, ok := interp.IsExitStatus(runErr); ok {
+		return executor.ExitStatusError(int(code), fmt.Sprintf("script exited with error: exit status %d", code))
+	}
+	return status.Error(codes.Internal, fmt.Sprintf("script failed: %v", runErr))
+}
+
+// execMiddleware 在每次外部命令调用前校验命令白名单与参数路径
+func (e *Executor) execMiddleware(taskCtx context.Context, pol *policy) func(next interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+	killTimeout := time.Duration(pol.cfg.KillTimeoutSec) * time.Second
+	return func(interp.ExecHandlerFunc) interp.ExecHandlerFunc {
+		next := interp.DefaultExecHandler(killTimeout)
+		return func(ctx context.Context, args []string) error {
+			hc := interp.HandlerCtx(ctx)
+			err := pol.checkCommand(args[0])
+			if err == nil {
+				err = pol.checkArgs(hc.Dir, args[1:])
+			}
+			if err != nil {
+				logit.Context(taskCtx).WarnW("logType", "interp command denied", "reason", err.Error())
+				_, _ = fmt.Fprintln(hc.Stderr, err.Error())
+				// 返回非退出码错误会中止整个脚本
+				return err
+			}
+			return next(ctx, args)
+		}
+	}
+}
+
+// openHandler 校验重定向、source 等文件打开操作
+func (e *Executor) openHandler(pol *policy) interp.OpenHandlerFunc {
+	next := interp.DefaultOpenHandler()
+	return func(ctx context.Context, path string, flag int, perm os.FileMode) (io.ReadWriteCloser, error) {
+		if err := pol.checkPath(interp.HandlerCtx(ctx).Dir, path, isWriteFlag(flag)); err != nil {
+			return nil, err
+		}
+		return next(ctx, path, flag, perm)
+	}
+}
+
+// readDirHandler 校验通配展开时的目录读取
+func (e *Executor) readDirHandler(pol *policy) interp.ReadDirHandlerFunc2 {
+	next := interp.DefaultReadDirHandler2()
+	return func(ctx context.Context, path string) ([]fs.DirEntry, error) {
+		if err := pol.checkPath(interp.HandlerCtx(ctx).Dir, path, false); err != nil {
+			return nil, err
+		}
+		return next(ctx, path)
+	}
+}
+
+// statHandler 校验 test、cd 等对文件状态的访问
+func (e *Executor) statHandler(pol *policy) interp.StatHandlerFunc {
+	next := interp.DefaultStatHandler()
+	return func(ctx context.Context, name string, followSymlinks bool) (fs.FileInfo, error) {
+		// 解释器传入的已是绝对路径，且此处上下文不携带 HandlerContext
+		if err := pol.checkPath("/", name, false); err != nil {
+			return nil, err
+		}
+		return next(ctx, name, followSymlinks)
+	}
+}
+
+// passEnv 按名称从 worker 环境中挑选允许继承的变量
+func passEnv(names []string) []string {
+	environ := make([]string, 0, len(names))
+	for _, name := range names {
+		if value, ok := os.LookupEnv(name); ok {
+			environ = append(environ, name+"="+value)
+		}
+	}
+	return environ
+}
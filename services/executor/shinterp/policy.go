@@ -0,0 +1,110 @@
+package shinterp
+
+import (
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor/shinterp/config"
+	"goumang-worker/services/internal/pathguard"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// errDenied 命令或文件访问被策略拒绝
+var errDenied = errors.New("denied by policy")
+
+// policy 运行时访问策略
+type policy struct {
+	cfg config.InterpExecutorConfig
+}
+
+// checkCommand 校验外部命令是否在白名单内
+func (p *policy) checkCommand(name string) error {
+	if strings.ContainsRune(name, '/') {
+		return fmt.Errorf("%w: command must be invoked by name: %q", errDenied, name)
+	}
+	if !slices.Contains(p.cfg.AllowedCommands, name) {
+		return fmt.Errorf("%w: command not allowed: %q", errDenied, name)
+	}
+	return nil
+}
+
+// checkArgs 校验外部命令参数：非选项参数与选项中附带的值都按路径校验，相对路径相对于 dir
+// 如 "conf/app.yaml"、"--file=/etc/shadow"、"-f/etc/passwd"；不含路径字符的短选项组合（如 "-la"）视为纯选项
+func (p *policy) checkArgs(dir string, args []string) error {
+	if !p.cfg.CheckArgPaths {
+		return nil
+	}
+	for _, arg := range args {
+		value, ok := argValue(arg)
+		if !ok {
+			continue
+		}
+		if err := p.checkPath(dir, value, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// argValue 返回参数中需要按路径校验的部分，纯选项返回 false
+func argValue(arg string) (string, bool) {
+	if arg == "" || arg == "-" || arg == "--" {
+		return "", false
+	}
+	if !strings.HasPrefix(arg, "-") {
+		return arg, true
+	}
+	if _, value, found := strings.Cut(arg, "="); found {
+		return value, value != ""
+	}
+	if !strings.HasPrefix(arg, "--") && len(arg) > 2 && strings.ContainsAny(arg[2:], "/.") {
+		return arg[2:], true
+	}
+	return "", false
+}
+
+// checkPath 校验路径是否位于允许的目录内，write 为 true 时要求可写
+func (p *policy) checkPath(dir, name string, write bool) error {
+	if name == "/dev/null" {
+		return nil
+	}
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(dir, name)
+	}
+	resolved := resolvePath(filepath.Clean(name))
+
+	roots := p.cfg.WritePaths
+	if !write {
+		roots = append(slices.Clone(p.cfg.ReadPaths), p.cfg.WritePaths...)
+	}
+	for _, root := range roots {
+		if pathguard.IsWithin(resolvePath(filepath.Clean(root)), resolved) {
+			return nil
+		}
+	}
+
+	access := "read"
+	if write {
+		access = "write"
+	}
+	return fmt.Errorf("%w: %s access to %q not allowed", errDenied, access, name)
+}
+
+// resolvePath 解析符号链接；文件尚不存在时解析其最近的已存在父目录
+func resolvePath(name string) string {
+	if resolved, err := filepath.EvalSymlinks(name); err == nil {
+		return resolved
+	}
+	parent := filepath.Dir(name)
+	if parent == name {
+		return name
+	}
+	return filepath.Join(resolvePath(parent), filepath.Base(name))
+}
+
+// isWriteFlag 判断打开标志是否包含写操作
+func isWriteFlag(flag int) bool {
+	return flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0
+}
@@ -0,0 +1,79 @@
+package shinterp
+
+import (
+	"errors"
+	"goumang-worker/services/executor/shinterp/config"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArgValue(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want string
+		ok   bool
+	}{
+		{"", "", false},
+		{"-", "", false},
+		{"--", "", false},
+		{"-la", "", false},
+		{"--verbose", "", false},
+		{"conf/app.yaml", "conf/app.yaml", true},
+		{"--file=/etc/shadow", "/etc/shadow", true},
+		{"--file=", "", false},
+		{"-f/etc/passwd", "/etc/passwd", true},
+		{"-f.hidden", ".hidden", true},
+		{"--output/etc", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := argValue(tt.arg)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("argValue(%q) = %q, %v; want %q, %v", tt.arg, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCheckPath(t *testing.T) {
+	root := t.TempDir()
+	readDir := filepath.Join(root, "read")
+	writeDir := filepath.Join(root, "write")
+	outside := filepath.Join(root, "outside")
+	for _, dir := range []string{readDir, writeDir, outside} {
+		if err := os.Mkdir(dir, 0o750); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 指向允许目录之外的符号链接
+	if err := os.Symlink(outside, filepath.Join(writeDir, "escape")); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &policy{cfg: config.InterpExecutorConfig{ReadPaths: []string{readDir}, WritePaths: []string{writeDir}}}
+	tests := []struct {
+		name  string
+		path  string
+		write bool
+		ok    bool
+	}{
+		{name: "read in read path", path: filepath.Join(readDir, "a"), ok: true},
+		{name: "read in write path", path: "a", ok: true},
+		{name: "write in write path", path: "new/file", write: true, ok: true},
+		{name: "write in read path", path: filepath.Join(readDir, "a"), write: true, ok: false},
+		{name: "dot dot escape", path: "../outside/a", ok: false},
+		{name: "prefix sibling", path: readDir + "2/a", ok: false},
+		{name: "symlink escape", path: "escape/a", write: true, ok: false},
+		{name: "dev null", path: "/dev/null", write: true, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.checkPath(writeDir, tt.path, tt.write)
+			if tt.ok && err != nil {
+				t.Errorf("checkPath(%q, %v) = %v, want nil", tt.path, tt.write, err)
+			}
+			if !tt.ok && !errors.Is(err, errDenied) {
+				t.Errorf("checkPath(%q, %v) = %v, want errDenied", tt.path, tt.write, err)
+			}
+		})
+	}
+}
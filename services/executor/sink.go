@@ -0,0 +1,91 @@
+package executor
+
+import (
+	"goumang-worker/services/pb"
+	"sync"
+)
+
+// Sink 执行器的输出目标，与 gRPC 流解耦，便于在 CLI、本地调度或测试中复用执行器
+// 实现需保证并发安全，执行器可能在多个 goroutine 中同时输出
+type Sink interface {
+	// Stdout 输出一行标准输出
+	Stdout(line string) error
+	// Stderr 输出一行标准错误
+	Stderr(line string) error
+	// Event 输出结构化事件
+	Event(event *pb.TaskEvent) error
+	// Heartbeat 输出心跳
+	Heartbeat(heartbeat *pb.Heartbeat) error
+	// Result 输出最终结果
+	Result(result *pb.TaskResult) error
+}
+
+// NodeSink 支持为输出标记节点名的 Sink（DAG 使用），不支持时输出不带节点标记
+type NodeSink interface {
+	Sink
+	// ForNode 返回为输出标记节点名的 Sink
+	ForNode(node string) Sink
+}
+
+// ForNode 返回标记节点名的 Sink，sink 不支持节点标记时原样返回
+func ForNode(sink Sink, node string) Sink {
+	if ns, ok := sink.(NodeSink); ok {
+		return ns.ForNode(node)
+	}
+	return sink
+}
+
+// ResponseSender 任务响应的发送端，pb.Task_RunServer 即满足
+type ResponseSender interface {
+	Send(*pb.TaskResponse) error
+}
+
+// streamSink gRPC 流适配器，串行化并发发送
+type streamSink struct {
+	mu     *sync.Mutex
+	stream ResponseSender
+	node   string
+}
+
+// NewStreamSink 创建写入 gRPC 任务流的 Sink
+func NewStreamSink(stream ResponseSender) NodeSink {
+	return &streamSink{mu: &sync.Mutex{}, stream: stream}
+}
+
+// ForNode 实现 NodeSink，与原 Sink 共享发送锁
+func (s *streamSink) ForNode(node string) Sink {
+	return &streamSink{mu: s.mu, stream: s.stream, node: node}
+}
+
+// Stdout 实现 Sink
+func (s *streamSink) Stdout(line string) error {
+	return s.send(&pb.TaskResponse{Content: &pb.TaskResponse_Output{Output: line}})
+}
+
+// Stderr 实现 Sink
+func (s *streamSink) Stderr(line string) error {
+	return s.send(&pb.TaskResponse{Content: &pb.TaskResponse_Error{Error: line}})
+}
+
+// Event 实现 Sink
+func (s *streamSink) Event(event *pb.TaskEvent) error {
+	return s.send(&pb.TaskResponse{Content: &pb.TaskResponse_Event{Event: event}})
+}
+
+// Heartbeat 实现 Sink
+func (s *streamSink) Heartbeat(heartbeat *pb.Heartbeat) error {
+	return s.send(&pb.TaskResponse{Content: &pb.TaskResponse_Heartbeat{Heartbeat: heartbeat}})
+}
+
+// Result 实现 Sink
+func (s *streamSink) Result(result *pb.TaskResult) error {
+	return s.send(&pb.TaskResponse{Content: &pb.TaskResponse_Result{Result: result}})
+}
+
+// send 加锁发送，gRPC 流不支持并发 Send
+func (s *streamSink) send(resp *pb.TaskResponse) error {
+	resp.Node = s.node
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stream.Send(resp)
+}
@@ -0,0 +1,187 @@
+package executor
+
+import (
+	"errors"
+	"goumang-worker/services/pb"
+	"io"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// jsonLinesSink 将每条输出按 protojson 编码为一行写入 Writer，适用于文件与 CLI
+type jsonLinesSink struct {
+	mu   *sync.Mutex
+	w    io.Writer
+	node string
+}
+
+// NewJSONLinesSink 创建写入 Writer 的 Sink，每条输出为一行 TaskResponse JSON
+func NewJSONLinesSink(w io.Writer) NodeSink {
+	return &jsonLinesSink{mu: &sync.Mutex{}, w: w}
+}
+
+// ForNode 实现 NodeSink
+func (s *jsonLinesSink) ForNode(node string) Sink {
+	return &jsonLinesSink{mu: s.mu, w: s.w, node: node}
+}
+
+// Stdout 实现 Sink
+func (s *jsonLinesSink) Stdout(line string) error {
+	return s.write(&pb.TaskResponse{Content: &pb.TaskResponse_Output{Output: line}})
+}
+
+// Stderr 实现 Sink
+func (s *jsonLinesSink) Stderr(line string) error {
+	return s.write(&pb.TaskResponse{Content: &pb.TaskResponse_Error{Error: line}})
+}
+
+// Event 实现 Sink
+func (s *jsonLinesSink) Event(event *pb.TaskEvent) error {
+	return s.write(&pb.TaskResponse{Content: &pb.TaskResponse_Event{Event: event}})
+}
+
+// Heartbeat 实现 Sink
+func (s *jsonLinesSink) Heartbeat(heartbeat *pb.Heartbeat) error {
+	return s.write(&pb.TaskResponse{Content: &pb.TaskResponse_Heartbeat{Heartbeat: heartbeat}})
+}
+
+// Result 实现 Sink
+func (s *jsonLinesSink) Result(result *pb.TaskResult) error {
+	return s.write(&pb.TaskResponse{Content: &pb.TaskResponse_Result{Result: result}})
+}
+
+// write 编码并写入一行
+func (s *jsonLinesSink) write(resp *pb.TaskResponse) error {
+	resp.Node = s.node
+	data, err := protojson.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(data, '\n'))
+	return err
+}
+
+// MemorySink 在内存中记录全部输出，适用于测试与本地调用
+type MemorySink struct {
+	mu        sync.Mutex
+	responses []*pb.TaskResponse
+}
+
+// NewMemorySink 创建内存 Sink
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+// Stdout 实现 Sink
+func (s *MemorySink) Stdout(line string) error {
+	return s.add(&pb.TaskResponse{Content: &pb.TaskResponse_Output{Output: line}})
+}
+
+// Stderr 实现 Sink
+func (s *MemorySink) Stderr(line string) error {
+	return s.add(&pb.TaskResponse{Content: &pb.TaskResponse_Error{Error: line}})
+}
+
+// Event 实现 Sink
+func (s *MemorySink) Event(event *pb.TaskEvent) error {
+	return s.add(&pb.TaskResponse{Content: &pb.TaskResponse_Event{Event: event}})
+}
+
+// Heartbeat 实现 Sink
+func (s *MemorySink) Heartbeat(heartbeat *pb.Heartbeat) error {
+	return s.add(&pb.TaskResponse{Content: &pb.TaskResponse_Heartbeat{Heartbeat: heartbeat}})
+}
+
+// Result 实现 Sink
+func (s *MemorySink) Result(result *pb.TaskResult) error {
+	return s.add(&pb.TaskResponse{Content: &pb.TaskResponse_Result{Result: result}})
+}
+
+// Responses 返回已记录输出的副本
+func (s *MemorySink) Responses() []*pb.TaskResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]*pb.TaskResponse, len(s.responses))
+	for i, resp := range s.responses {
+		list[i] = proto.Clone(resp).(*pb.TaskResponse)
+	}
+	return list
+}
+
+// Stdouts 返回全部标准输出行
+func (s *MemorySink) Stdouts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var lines []string
+	for _, resp := range s.responses {
+		if out, ok := resp.Content.(*pb.TaskResponse_Output); ok {
+			lines = append(lines, out.Output)
+		}
+	}
+	return lines
+}
+
+// add 记录一条输出
+func (s *MemorySink) add(resp *pb.TaskResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+// fanOutSink 将输出同时写入多个 Sink
+type fanOutSink []Sink
+
+// FanOut 创建同时写入多个 Sink 的 Sink，所有目标都会被写入，返回合并后的错误
+func FanOut(sinks ...Sink) NodeSink {
+	return fanOutSink(sinks)
+}
+
+// ForNode 实现 NodeSink
+func (f fanOutSink) ForNode(node string) Sink {
+	tagged := make(fanOutSink, len(f))
+	for i, sink := range f {
+		tagged[i] = ForNode(sink, node)
+	}
+	return tagged
+}
+
+// Stdout 实现 Sink
+func (f fanOutSink) Stdout(line string) error {
+	return f.each(func(s Sink) error { return s.Stdout(line) })
+}
+
+// Stderr 实现 Sink
+func (f fanOutSink) Stderr(line string) error {
+	return f.each(func(s Sink) error { return s.Stderr(line) })
+}
+
+// Event 实现 Sink
+func (f fanOutSink) Event(event *pb.TaskEvent) error {
+	return f.each(func(s Sink) error { return s.Event(event) })
+}
+
+// Heartbeat 实现 Sink
+func (f fanOutSink) Heartbeat(heartbeat *pb.Heartbeat) error {
+	return f.each(func(s Sink) error { return s.Heartbeat(heartbeat) })
+}
+
+// Result 实现 Sink
+func (f fanOutSink) Result(result *pb.TaskResult) error {
+	return f.each(func(s Sink) error { return s.Result(result) })
+}
+
+// each 依次写入每个目标
+func (f fanOutSink) each(fn func(Sink) error) error {
+	var errs []error
+	for _, sink := range f {
+		if err := fn(sink); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,183 @@
+package executor
+
+import (
+	"goumang-worker/services/pb"
+	"regexp"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SinkMiddleware Sink 中间件，用于组合输出过滤（脱敏、截断等）
+type SinkMiddleware func(next Sink) Sink
+
+// ChainSink 依次套用中间件，第一个中间件最先处理输出
+func ChainSink(sink Sink, middlewares ...SinkMiddleware) Sink {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		sink = middlewares[i](sink)
+	}
+	return sink
+}
+
+// LineFilter 处理一行输出，返回 false 时丢弃该行
+type LineFilter func(line string, isErr bool) (string, bool)
+
+// FilterLines 创建按行过滤 stdout/stderr 的中间件，事件、心跳与结果原样透传
+func FilterLines(filter LineFilter) SinkMiddleware {
+	return func(next Sink) Sink {
+		return &filterSink{next: next, filter: filter}
+	}
+}
+
+// MaskLines 创建脱敏中间件，输出行以及事件、心跳与结果中字符串字段的匹配内容替换为 replacement
+func MaskLines(patterns []*regexp.Regexp, replacement string) SinkMiddleware {
+	mask := func(s string) string {
+		for _, re := range patterns {
+			s = re.ReplaceAllString(s, replacement)
+		}
+		return s
+	}
+	return func(next Sink) Sink {
+		return &maskSink{
+			filterSink: filterSink{next: next, filter: func(line string, _ bool) (string, bool) {
+				return mask(line), true
+			}},
+			mask: mask,
+		}
+	}
+}
+
+// TruncateLines 创建截断过长行的中间件，超出 maxBytes 的部分替换为 "..."
+func TruncateLines(maxBytes int) SinkMiddleware {
+	return FilterLines(func(line string, _ bool) (string, bool) {
+		if maxBytes <= 0 || len(line) <= maxBytes {
+			return line, true
+		}
+		// 退到字符边界，避免截断多字节字符
+		cut := maxBytes
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		return line[:cut] + "...", true
+	})
+}
+
+// filterSink 按行过滤的 Sink
+type filterSink struct {
+	next   Sink
+	filter LineFilter
+}
+
+// ForNode 实现 NodeSink，节点标记交给下游 Sink
+func (f *filterSink) ForNode(node string) Sink {
+	return &filterSink{next: ForNode(f.next, node), filter: f.filter}
+}
+
+// Stdout 实现 Sink
+func (f *filterSink) Stdout(line string) error {
+	if line, ok := f.filter(line, false); ok {
+		return f.next.Stdout(line)
+	}
+	return nil
+}
+
+// Stderr 实现 Sink
+func (f *filterSink) Stderr(line string) error {
+	if line, ok := f.filter(line, true); ok {
+		return f.next.Stderr(line)
+	}
+	return nil
+}
+
+// Event 实现 Sink
+func (f *filterSink) Event(event *pb.TaskEvent) error {
+	return f.next.Event(event)
+}
+
+// Heartbeat 实现 Sink
+func (f *filterSink) Heartbeat(heartbeat *pb.Heartbeat) error {
+	return f.next.Heartbeat(heartbeat)
+}
+
+// Result 实现 Sink
+func (f *filterSink) Result(result *pb.TaskResult) error {
+	return f.next.Result(result)
+}
+
+// maskSink 脱敏 Sink，在按行过滤之外处理消息中的字符串字段
+type maskSink struct {
+	filterSink
+	mask func(string) string
+}
+
+// ForNode 实现 NodeSink，节点标记交给下游 Sink
+func (m *maskSink) ForNode(node string) Sink {
+	return &maskSink{filterSink: filterSink{next: ForNode(m.next, node), filter: m.filter}, mask: m.mask}
+}
+
+// Event 实现 Sink
+func (m *maskSink) Event(event *pb.TaskEvent) error {
+	return m.next.Event(maskMessage(event, m.mask))
+}
+
+// Heartbeat 实现 Sink
+func (m *maskSink) Heartbeat(heartbeat *pb.Heartbeat) error {
+	return m.next.Heartbeat(maskMessage(heartbeat, m.mask))
+}
+
+// Result 实现 Sink
+func (m *maskSink) Result(result *pb.TaskResult) error {
+	return m.next.Result(maskMessage(result, m.mask))
+}
+
+// maskMessage 复制消息并替换其中所有字符串字段（含重复字段、map 值与嵌套消息），不修改调用方的消息
+func maskMessage[T proto.Message](msg T, mask func(string) string) T {
+	if !msg.ProtoReflect().IsValid() {
+		return msg
+	}
+	msg = proto.Clone(msg).(T)
+	maskFields(msg.ProtoReflect(), mask)
+	return msg
+}
+
+// maskFields 递归替换消息中的字符串字段
+func maskFields(m protoreflect.Message, mask func(string) string) {
+	// 遍历期间不修改消息，先收集再写回
+	var updates []func()
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				switch fd.Kind() {
+				case protoreflect.StringKind:
+					list.Set(i, protoreflect.ValueOfString(mask(list.Get(i).String())))
+				case protoreflect.MessageKind, protoreflect.GroupKind:
+					maskFields(list.Get(i).Message(), mask)
+				}
+			}
+		case fd.IsMap():
+			mp := v.Map()
+			mp.Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+				switch fd.MapValue().Kind() {
+				case protoreflect.StringKind:
+					masked := protoreflect.ValueOfString(mask(mv.String()))
+					updates = append(updates, func() { mp.Set(k, masked) })
+				case protoreflect.MessageKind, protoreflect.GroupKind:
+					maskFields(mv.Message(), mask)
+				}
+				return true
+			})
+		case fd.Kind() == protoreflect.StringKind:
+			masked := protoreflect.ValueOfString(mask(v.String()))
+			updates = append(updates, func() { m.Set(fd, masked) })
+		case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+			maskFields(v.Message(), mask)
+		}
+		return true
+	})
+	for _, update := range updates {
+		update()
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// Datasource SQLite 数据源配置
+type Datasource struct {
+	// 数据源名称，请求通过名称引用
+	Name string `yaml:"name"`
+	// 数据库文件路径
+	Path string `yaml:"path"`
+	// 是否只读
+	ReadOnly bool `yaml:"readOnly"`
+}
+
+// SQLExecutorConfig SQL 执行器配置
+type SQLExecutorConfig struct {
+	Datasources []Datasource `yaml:"datasources"`
+	// 单次查询最多返回的行数，0 表示不限制
+	MaxRows int `yaml:"maxRows"`
+}
+
+// Config SQL 配置结构
+type Config struct {
+	SQL SQLExecutorConfig `yaml:"sql"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "sql.yaml"), &globalConfig); err != nil {
+			panic("loadConfig sql.yaml err:" + err.Error())
+		}
+	})
+}
+
+// GetSQLConfig 获取 SQL 执行器配置
+func GetSQLConfig() SQLExecutorConfig {
+	lazyLoadConfig()
+	return globalConfig.SQL
+}
+
+// GetDatasource 按名称查找数据源
+func GetDatasource(name string) (Datasource, bool) {
+	for _, ds := range GetSQLConfig().Datasources {
+		if ds.Name == name {
+			return ds, true
+		}
+	}
+	return Datasource{}, false
+}
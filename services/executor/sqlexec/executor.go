@@ -0,0 +1,224 @@
+package sqlexec
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/sqlexec/config"
+	"goumang-worker/services/pb"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"github.com/mattn/go-sqlite3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// init 自动注册 SQL 执行器到默认工厂
+func init() {
+	executor.RegisterExecutor(pb.Method_SQL.String(), NewExecutor)
+	executor.RegisterParams(pb.Method_SQL.String(), executor.ParamsSpec{
+		Version:     1,
+		Description: "SQL statement against a configured SQLite datasource",
+		Params:      Params{},
+	})
+}
+
+// Params SQL 执行参数（method_params 的 JSON 内容）
+type Params struct {
+	// 配置中的数据源名称
+	Datasource string `json:"datasource" schema:"required,minLength=1"`
+	// SQL 语句
+	Query string `json:"query" schema:"required,minLength=1"`
+	// 绑定参数
+	Args []any `json:"args"`
+}
+
+// queryKeywords 返回结果集的语句前缀，其余语句按 Exec 执行
+var queryKeywords = []string{"SELECT", "WITH", "PRAGMA", "EXPLAIN", "VALUES"}
+
+var (
+	dbMap = make(map[string]*sql.DB)
+	dbMu  sync.Mutex
+)
+
+// Executor SQLite 查询执行器
+type Executor struct{}
+
+// NewExecutor 创建新的 SQL 执行器
+func NewExecutor() executor.Executor {
+	return &Executor{}
+}
+
+// Execute 执行 SQL，查询结果以列头事件加 JSON 行的形式返回
+func (e *Executor) Execute(ctx context.Context, params string, sink executor.Sink) error {
+	p, err := executor.DecodeParams[Params](ctx, params)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid params: %v", err))
+	}
+	p.Query = strings.TrimSpace(p.Query)
+	if p.Query == "" {
+		return status.Error(codes.InvalidArgument, "empty query")
+	}
+
+	ds, ok := config.GetDatasource(p.Datasource)
+	if !ok {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("unknown datasource: %q", p.Datasource))
+	}
+	db, err := getDB(ds)
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("open datasource failed: %v", err))
+	}
+
+	if !isQuery(p.Query) {
+		return e.exec(ctx, db, *p, sink)
+	}
+	return e.query(ctx, db, *p, sink)
+}
+
+// query 执行查询并逐行转发
+func (e *Executor) query(ctx context.Context, db *sql.DB, p Params, sink executor.Sink) error {
+	rows, err := db.QueryContext(ctx, p.Query, p.Args...)
+	if err != nil {
+		return e.queryError(ctx, err)
+	}
+	defer func() {
+		if errC := rows.Close(); errC != nil {
+			logit.Context(ctx).WarnW("rows.Close.Err", errC)
+		}
+	}()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("read columns failed: %v", err))
+	}
+	columns := &pb.Columns{}
+	for _, ct := range columnTypes {
+		columns.Names = append(columns.Names, ct.Name())
+		columns.Types = append(columns.Types, ct.DatabaseTypeName())
+	}
+	if err = sink.Event(&pb.TaskEvent{
+		Event: &pb.TaskEvent_Columns{Columns: columns},
+	}); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("failed to send output: %v", err))
+	}
+
+	maxRows := config.GetSQLConfig().MaxRows
+	result := &pb.TaskResult{}
+	values := make([]any, len(columnTypes))
+	scanArgs := make([]any, len(columnTypes))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	for rows.Next() {
+		if maxRows > 0 && result.TotalLines >= uint64(maxRows) {
+			result.Truncated = true
+			break
+		}
+		if err = rows.Scan(scanArgs...); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("scan row failed: %v", err))
+		}
+		for i, v := range values {
+			// TEXT 以外的字节数组按字符串输出
+			if b, isBytes := v.([]byte); isBytes {
+				values[i] = string(b)
+			}
+		}
+		line, errM := json.Marshal(values)
+		if errM != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("encode row failed: %v", errM))
+		}
+		if err = sink.Stdout(string(line)); err != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("failed to send output: %v", err))
+		}
+		result.TotalLines++
+		result.ForwardedLines++
+		result.TotalBytes += uint64(len(line)) + 1
+		result.ForwardedBytes += uint64(len(line)) + 1
+	}
+	if err = rows.Err(); err != nil {
+		return e.queryError(ctx, err)
+	}
+
+	if errS := sink.Result(result); errS != nil {
+		logit.Context(ctx).WarnW("result.stream.Send.Err", errS)
+	}
+	return nil
+}
+
+// exec 执行非查询语句，以指标事件返回影响行数
+func (e *Executor) exec(ctx context.Context, db *sql.DB, p Params, sink executor.Sink) error {
+	res, err := db.ExecContext(ctx, p.Query, p.Args...)
+	if err != nil {
+		return e.queryError(ctx, err)
+	}
+
+	metrics := make(map[string]string)
+	if affected, errA := res.RowsAffected(); errA == nil {
+		metrics["rows_affected"] = strconv.FormatInt(affected, 10)
+	}
+	if lastID, errL := res.LastInsertId(); errL == nil {
+		metrics["last_insert_id"] = strconv.FormatInt(lastID, 10)
+	}
+	if err = sink.Event(&pb.TaskEvent{
+		Event: &pb.TaskEvent_Metrics{Metrics: &pb.Metrics{Values: metrics}},
+	}); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("failed to send output: %v", err))
+	}
+
+	if errS := sink.Result(&pb.TaskResult{}); errS != nil {
+		logit.Context(ctx).WarnW("result.stream.Send.Err", errS)
+	}
+	return nil
+}
+
+// queryError 转换执行错误，区分超时取消
+func (e *Executor) queryError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("query canceled or timeout: %v", ctx.Err()))
+	}
+	logit.Context(ctx).WarnW("sql.Err", err)
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrReadonly {
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("datasource is read-only: %v", err))
+	}
+	return status.Error(codes.Internal, fmt.Sprintf("query failed: %v", err))
+}
+
+// isQuery 判断语句是否返回结果集
+func isQuery(query string) bool {
+	upper := strings.ToUpper(query)
+	for _, keyword := range queryKeywords {
+		if strings.HasPrefix(upper, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// getDB 获取数据源连接，按名称复用
+func getDB(ds config.Datasource) (*sql.DB, error) {
+	dbMu.Lock()
+	defer dbMu.Unlock()
+
+	if db, ok := dbMap[ds.Name]; ok {
+		return db, nil
+	}
+
+	dsn := "file:" + ds.Path
+	if ds.ReadOnly {
+		// 只读模式：文件以只读方式打开，并禁止任何写入语句
+		dsn += "?mode=ro&_query_only=true"
+	}
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	dbMap[ds.Name] = db
+	return db, nil
+}
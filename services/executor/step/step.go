@@ -0,0 +1,114 @@
+package step
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/pb"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// Invocation 校验后的方法调用
+type Invocation struct {
+	Method string
+	Params string
+	// 超时秒数，0 表示只受整个任务超时限制
+	TimeoutSec int
+}
+
+// Outcome 一次调用的执行结果
+type Outcome struct {
+	Result     *pb.TaskResult
+	ExitCode   int32
+	Error      string
+	DurationMs int64
+	Err        error
+}
+
+// Resolve 解析方法名与参数，denied 中的方法（如编排类方法自身）不允许调用
+func Resolve(method string, raw json.RawMessage, timeoutSec int, denied ...string) (Invocation, error) {
+	for _, d := range denied {
+		if method == d {
+			return Invocation{}, fmt.Errorf("method %s is not allowed here", method)
+		}
+	}
+	if !executor.IsSupported(method) {
+		return Invocation{}, fmt.Errorf("unknown method %q", method)
+	}
+	if timeoutSec < 0 {
+		return Invocation{}, fmt.Errorf("negative timeout")
+	}
+	params, err := paramsString(raw)
+	if err != nil {
+		return Invocation{}, err
+	}
+	if err = executor.ValidateParams(method, 0, params); err != nil {
+		return Invocation{}, errors.New(status.Convert(err).Message())
+	}
+	return Invocation{Method: method, Params: params, TimeoutSec: timeoutSec}, nil
+}
+
+// Run 创建执行器并执行调用，输出经由 stream 转发到父 Sink
+func Run(ctx context.Context, inv Invocation, stream *Stream) *Outcome {
+	start := time.Now()
+	if inv.TimeoutSec > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(inv.TimeoutSec)*time.Second)
+		defer cancel()
+	}
+
+	exec, err := executor.CreateExecutor(inv.Method)
+	if err == nil {
+		err = exec.Execute(ctx, inv.Params, stream)
+	}
+
+	out := &Outcome{
+		Result:     stream.Captured(),
+		ExitCode:   executor.ExitCode(err),
+		DurationMs: time.Since(start).Milliseconds(),
+		Err:        err,
+	}
+	if err != nil {
+		out.Error = status.Convert(err).Message()
+	}
+	return out
+}
+
+// WrapError 为子任务错误附加前缀，保留原状态码与详情（如退出码）
+func WrapError(prefix string, err error) error {
+	st := status.Convert(err).Proto()
+	st.Message = prefix + ": " + st.Message
+	return status.ErrorProto(st)
+}
+
+// EnvList 将环境变量按名称排序后转换为 "KEY=VALUE" 列表
+func EnvList(env map[string]string) []string {
+	list := make([]string, 0, len(env))
+	for key, value := range env {
+		list = append(list, key+"="+value)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// paramsString 将参数转换为 method_params 字符串：字符串原样传递，对象或数组按 JSON 文本传递
+func paramsString(raw json.RawMessage) (string, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return "", nil
+	}
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return "", fmt.Errorf("invalid params: %v", err)
+		}
+		return s, nil
+	}
+	return string(raw), nil
+}
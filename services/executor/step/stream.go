@@ -0,0 +1,51 @@
+package step
+
+import (
+	"goumang-worker/services/executor"
+	"goumang-worker/services/pb"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Stream 包装父 Sink：转发子任务的输出与事件，截留子任务自身的最终结果
+type Stream struct {
+	executor.Sink
+	mu     sync.Mutex
+	result *pb.TaskResult
+}
+
+// NewStream 创建子任务输出，node 非空时为转发的输出标记节点名
+func NewStream(parent executor.Sink, node string) *Stream {
+	if node != "" {
+		parent = executor.ForNode(parent, node)
+	}
+	return &Stream{Sink: parent}
+}
+
+// Result 实现 executor.Sink，最终结果不转发，由调用方汇总后统一输出
+func (s *Stream) Result(result *pb.TaskResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.result = proto.Clone(result).(*pb.TaskResult)
+	return nil
+}
+
+// Captured 返回子任务的最终结果，未输出结果时返回空结果
+func (s *Stream) Captured() *pb.TaskResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.result == nil {
+		return &pb.TaskResult{}
+	}
+	return s.result
+}
+
+// MergeResult 将子任务结果累加到总结果
+func MergeResult(total, sub *pb.TaskResult) {
+	total.TotalBytes += sub.TotalBytes
+	total.ForwardedBytes += sub.ForwardedBytes
+	total.TotalLines += sub.TotalLines
+	total.ForwardedLines += sub.ForwardedLines
+	total.Truncated = total.Truncated || sub.Truncated
+}
@@ -0,0 +1,30 @@
+package executor
+
+import "context"
+
+// TaskInfo 任务元信息，由服务端注入上下文供执行器读取
+type TaskInfo struct {
+	// RunTaskID 调度端的运行 ID
+	RunTaskID uint64
+	// Artifacts 任务结束后需要收集的产物匹配模式（相对于工作目录）
+	Artifacts []string
+	// WorkDir 指定时作为工作目录，执行器不再创建独立的临时目录（流水线各步骤共享）
+	WorkDir string
+	// Env 追加的环境变量，形如 "KEY=VALUE"
+	Env []string
+}
+
+type taskInfoKey struct{}
+
+// WithTaskInfo 将任务元信息写入上下文
+func WithTaskInfo(ctx context.Context, info *TaskInfo) context.Context {
+	return context.WithValue(ctx, taskInfoKey{}, info)
+}
+
+// TaskInfoFromContext 从上下文读取任务元信息，不存在时返回空信息
+func TaskInfoFromContext(ctx context.Context) *TaskInfo {
+	if info, ok := ctx.Value(taskInfoKey{}).(*TaskInfo); ok && info != nil {
+		return info
+	}
+	return &TaskInfo{}
+}
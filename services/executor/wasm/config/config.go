@@ -0,0 +1,73 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// WasmExecutorConfig WebAssembly 执行器配置
+type WasmExecutorConfig struct {
+	// 模块缓存目录
+	ModuleCacheDir string `yaml:"moduleCacheDir"`
+	// 是否允许内联提交模块
+	AllowInline bool `yaml:"allowInline"`
+	// 模块最大字节数
+	MaxModuleBytes int `yaml:"maxModuleBytes"`
+	// 模块缓存总字节数上限，超出时按最近使用时间淘汰
+	ModuleCacheMaxBytes int64 `yaml:"moduleCacheMaxBytes"`
+	// 模块缓存未使用的保留小时数（0 表示不过期）
+	ModuleCacheTTLHours int `yaml:"moduleCacheTTLHours"`
+	// 线性内存上限页数（每页 64KiB）
+	MaxMemoryPages uint32 `yaml:"maxMemoryPages"`
+	// 单次执行最长秒数
+	MaxExecutionSec int `yaml:"maxExecutionSec"`
+	// 单次执行的燃料（函数调用次数）上限，0 表示不限制
+	MaxFuel int64 `yaml:"maxFuel"`
+	// 临时目录根目录
+	ScratchRoot string `yaml:"scratchRoot"`
+}
+
+// Config WebAssembly 配置结构
+type Config struct {
+	Wasm WasmExecutorConfig `yaml:"wasm"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "wasm.yaml"), &globalConfig); err != nil {
+			panic("loadConfig wasm.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if globalConfig.Wasm.ModuleCacheDir == "" {
+			globalConfig.Wasm.ModuleCacheDir = path.Join(env.RootPath(), "data", "wasm", "modules")
+		}
+		if globalConfig.Wasm.ScratchRoot == "" {
+			globalConfig.Wasm.ScratchRoot = path.Join(env.RootPath(), "data", "wasm", "scratch")
+		}
+		if globalConfig.Wasm.MaxModuleBytes <= 0 {
+			globalConfig.Wasm.MaxModuleBytes = 3000000
+		}
+		if globalConfig.Wasm.ModuleCacheMaxBytes <= 0 {
+			globalConfig.Wasm.ModuleCacheMaxBytes = 1 << 30
+		}
+		if globalConfig.Wasm.MaxMemoryPages == 0 {
+			globalConfig.Wasm.MaxMemoryPages = 2048
+		}
+	})
+}
+
+// GetWasmConfig 获取 WebAssembly 执行器配置
+func GetWasmConfig() WasmExecutorConfig {
+	lazyLoadConfig()
+	return globalConfig.Wasm
+}
@@ -0,0 +1,180 @@
+package wasm
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"goumang-worker/services/artifact"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/wasm/config"
+	"goumang-worker/services/pb"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/experimental"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// init 自动注册 WebAssembly 执行器到默认工厂
+func init() {
+	executor.RegisterExecutor(pb.Method_WASM.String(), NewExecutor)
+	executor.RegisterParams(pb.Method_WASM.String(), executor.ParamsSpec{
+		Version:     1,
+		Description: "WASI module run in an in-process sandbox",
+		Params:      Params{},
+	})
+}
+
+// Params WebAssembly 执行参数（method_params 的 JSON 内容）
+type Params struct {
+	// 内联模块内容（JSON 中为 base64）
+	Module []byte `json:"module"`
+	// 模块 sha256，未提供 module 时从缓存读取
+	ModuleHash string `json:"moduleHash"`
+	// 传给模块的参数
+	Args []string `json:"args"`
+	// 传给模块的环境变量
+	Env map[string]string `json:"env"`
+}
+
+// Executor WASI 模块执行器
+type Executor struct{}
+
+// NewExecutor 创建新的 WebAssembly 执行器
+func NewExecutor() executor.Executor {
+	return &Executor{}
+}
+
+// Execute 在进程内运行 WASI 模块
+func (e *Executor) Execute(ctx context.Context, params string, sink executor.Sink) error {
+	cfg := config.GetWasmConfig()
+
+	p, err := executor.DecodeParams[Params](ctx, params)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid params: %v", err))
+	}
+	module, hash, err := loadModule(cfg, p)
+	if err != nil {
+		return err
+	}
+
+	if cfg.MaxExecutionSec > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.MaxExecutionSec)*time.Second)
+		defer cancel()
+	}
+
+	// 燃料计量需在编译前挂到上下文，编译出的函数才会回调计量器
+	var fuel *fuelMeter
+	if cfg.MaxFuel > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		fuel = newFuelMeter(cfg.MaxFuel, cancel)
+		ctx = experimental.WithFunctionListenerFactory(ctx, fuel)
+	}
+
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(cfg.MaxMemoryPages).
+		WithCloseOnContextDone(true))
+	defer func() {
+		if errC := runtime.Close(context.Background()); errC != nil {
+			logit.Context(ctx).WarnW("runtime.Close.Err", errC)
+		}
+	}()
+
+	if _, err = wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("instantiate wasi failed: %v", err))
+	}
+	compiled, err := runtime.CompileModule(ctx, module)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid module: %v", err))
+	}
+	if len(p.Module) > 0 {
+		if errS := storeModule(cfg, hash, module); errS != nil {
+			logit.Context(ctx).WarnW("storeModule.Err", errS)
+		}
+	}
+
+	// 每次运行独立的临时目录，作为模块唯一可见的文件系统
+	taskInfo := executor.TaskInfoFromContext(ctx)
+	if err = os.MkdirAll(cfg.ScratchRoot, 0o750); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("create scratch root failed: %v", err))
+	}
+	scratch, err := os.MkdirTemp(cfg.ScratchRoot, strconv.FormatUint(taskInfo.RunTaskID, 10)+"-")
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("create scratch dir failed: %v", err))
+	}
+	defer func() {
+		if errR := os.RemoveAll(scratch); errR != nil {
+			logit.Context(ctx).WarnW("scratch.RemoveAll.Err", errR)
+		}
+	}()
+
+	sender := executor.NewOutputSender(sink)
+	stdout := executor.NewLineWriter(sender, false)
+	stderr := executor.NewLineWriter(sender, true)
+
+	moduleConfig := wazero.NewModuleConfig().
+		WithName("").
+		WithArgs(append([]string{"module"}, p.Args...)...).
+		WithStdout(stdout).
+		WithStderr(stderr).
+		WithFSConfig(wazero.NewFSConfig().WithDirMount(scratch, "/")).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader)
+	for key, value := range p.Env {
+		moduleConfig = moduleConfig.WithEnv(key, value)
+	}
+
+	mod, runErr := runtime.InstantiateModule(ctx, compiled, moduleConfig)
+	if mod != nil {
+		_ = mod.Close(context.Background())
+	}
+	stdout.Flush()
+	stderr.Flush()
+
+	if errS := sender.Err(); errS != nil {
+		logit.Context(ctx).WarnW("stream.Send.Err", errS)
+		return status.Error(codes.Internal, fmt.Sprintf("failed to send output: %v", errS))
+	}
+	result := sender.Result()
+	result.Artifacts = artifact.Collect(ctx, taskInfo.RunTaskID, scratch, taskInfo.Artifacts)
+	if errS := sink.Result(result); errS != nil {
+		logit.Context(ctx).WarnW("result.stream.Send.Err", errS)
+	}
+
+	if fuel != nil && fuel.exhausted.Load() {
+		return status.Error(codes.ResourceExhausted, fmt.Sprintf("module exhausted its fuel of %d function calls", cfg.MaxFuel))
+	}
+	return e.runError(ctx, runErr)
+}
+
+// runError 转换模块运行错误，退出码为 0 视为成功
+func (e *Executor) runError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var exitErr *sys.ExitError
+	if errors.As(err, &exitErr) {
+		switch exitErr.ExitCode() {
+		case 0:
+			return nil
+		case sys.ExitCodeDeadlineExceeded, sys.ExitCodeContextCanceled:
+			return status.Error(codes.Internal, fmt.Sprintf("module canceled or timeout: %v", ctx.Err()))
+		default:
+			return executor.ExitStatusError(int(exitErr.ExitCode()), fmt.Sprintf("module exited with error: exit status %d", exitErr.ExitCode()))
+		}
+	}
+	logit.Context(ctx).WarnW("wasm.run.Err", err)
+	return status.Error(codes.Internal, fmt.Sprintf("module failed: %v", err))
+}
@@ -0,0 +1,42 @@
+package wasm
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+)
+
+// fuelMeter 以函数调用次数作为燃料计量，燃料耗尽后取消模块执行
+// 运行时在函数入口与循环回边检查取消，因此耗尽后模块会在下一次检查时中止
+type fuelMeter struct {
+	remaining atomic.Int64
+	exhausted atomic.Bool
+	cancel    context.CancelFunc
+}
+
+// newFuelMeter 创建燃料计量器，cancel 用于中止模块执行
+func newFuelMeter(fuel int64, cancel context.CancelFunc) *fuelMeter {
+	m := &fuelMeter{cancel: cancel}
+	m.remaining.Store(fuel)
+	return m
+}
+
+// NewFunctionListener 实现 experimental.FunctionListenerFactory，所有函数共用同一计量器
+func (m *fuelMeter) NewFunctionListener(api.FunctionDefinition) experimental.FunctionListener {
+	return m
+}
+
+// Before 每次函数调用消耗一个单位燃料
+func (m *fuelMeter) Before(context.Context, api.Module, api.FunctionDefinition, []uint64, experimental.StackIterator) {
+	if m.remaining.Add(-1) < 0 && !m.exhausted.Swap(true) {
+		m.cancel()
+	}
+}
+
+// After 实现 experimental.FunctionListener
+func (m *fuelMeter) After(context.Context, api.Module, api.FunctionDefinition, []uint64) {}
+
+// Abort 实现 experimental.FunctionListener
+func (m *fuelMeter) Abort(context.Context, api.Module, api.FunctionDefinition, error) {}
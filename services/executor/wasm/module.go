@@ -0,0 +1,173 @@
+package wasm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"goumang-worker/services/executor/wasm/config"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loadModule 获取模块字节：内联提交或按 sha256 从缓存读取，返回内容与哈希
+func loadModule(cfg config.WasmExecutorConfig, p *Params) ([]byte, string, error) {
+	if len(p.Module) > 0 {
+		if !cfg.AllowInline {
+			return nil, "", status.Error(codes.PermissionDenied, "inline modules are not allowed")
+		}
+		if len(p.Module) > cfg.MaxModuleBytes {
+			return nil, "", status.Error(codes.InvalidArgument, fmt.Sprintf("module exceeds %d bytes", cfg.MaxModuleBytes))
+		}
+		hash := moduleHash(p.Module)
+		if p.ModuleHash != "" && !strings.EqualFold(p.ModuleHash, hash) {
+			return nil, "", status.Error(codes.InvalidArgument, fmt.Sprintf("module sha256 mismatch: expected %s, got %s", p.ModuleHash, hash))
+		}
+		return p.Module, hash, nil
+	}
+
+	if p.ModuleHash == "" {
+		return nil, "", status.Error(codes.InvalidArgument, "module or moduleHash is required")
+	}
+	hash := strings.ToLower(p.ModuleHash)
+	if !isHexHash(hash) {
+		return nil, "", status.Error(codes.InvalidArgument, fmt.Sprintf("invalid moduleHash: %q", p.ModuleHash))
+	}
+
+	target := cachePath(cfg, hash)
+	if info, errS := os.Stat(target); errS == nil && isExpired(cfg, info.ModTime()) {
+		_ = os.Remove(target)
+		return nil, "", status.Error(codes.NotFound, fmt.Sprintf("module %s not found in cache", hash))
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, "", status.Error(codes.NotFound, fmt.Sprintf("module %s not found in cache", hash))
+		}
+		return nil, "", status.Error(codes.Internal, fmt.Sprintf("read module failed: %v", err))
+	}
+	// 防止缓存文件被篡改
+	if moduleHash(data) != hash {
+		return nil, "", status.Error(codes.DataLoss, fmt.Sprintf("cached module %s is corrupted", hash))
+	}
+	// 以修改时间记录最近使用时间，用于过期与淘汰
+	now := time.Now()
+	_ = os.Chtimes(target, now, now)
+	return data, hash, nil
+}
+
+// cacheMu 串行化缓存淘汰
+var cacheMu sync.Mutex
+
+// storeModule 将已通过编译校验的内联模块写入缓存，已存在时刷新使用时间
+func storeModule(cfg config.WasmExecutorConfig, hash string, data []byte) error {
+	target := cachePath(cfg, hash)
+	if _, err := os.Stat(target); err == nil {
+		now := time.Now()
+		return os.Chtimes(target, now, now)
+	}
+	if err := os.MkdirAll(cfg.ModuleCacheDir, 0o750); err != nil {
+		return err
+	}
+
+	// 先写临时文件再重命名，避免并发读到不完整的模块
+	tmp, err := os.CreateTemp(cfg.ModuleCacheDir, hash+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Close()
+	} else {
+		_ = tmp.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), target)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return pruneCache(cfg)
+}
+
+// pruneCache 删除过期模块，总大小超出上限时按使用时间从旧到新删除
+func pruneCache(cfg config.WasmExecutorConfig) error {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	entries, err := os.ReadDir(cfg.ModuleCacheDir)
+	if err != nil {
+		return err
+	}
+
+	type cached struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+	var (
+		modules    []cached
+		totalBytes int64
+	)
+	for _, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".wasm" {
+			continue
+		}
+		info, errI := entry.Info()
+		if errI != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		modulePath := filepath.Join(cfg.ModuleCacheDir, entry.Name())
+		if isExpired(cfg, info.ModTime()) {
+			_ = os.Remove(modulePath)
+			continue
+		}
+		modules = append(modules, cached{path: modulePath, size: info.Size(), modTime: info.ModTime()})
+		totalBytes += info.Size()
+	}
+
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].modTime.Before(modules[j].modTime)
+	})
+	for _, m := range modules {
+		if totalBytes <= cfg.ModuleCacheMaxBytes {
+			break
+		}
+		if errR := os.Remove(m.path); errR != nil {
+			return errR
+		}
+		totalBytes -= m.size
+	}
+	return nil
+}
+
+// isExpired 模块超过保留期未被使用
+func isExpired(cfg config.WasmExecutorConfig, lastUsed time.Time) bool {
+	return cfg.ModuleCacheTTLHours > 0 && time.Since(lastUsed) > time.Duration(cfg.ModuleCacheTTLHours)*time.Hour
+}
+
+// cachePath 返回模块在缓存中的路径
+func cachePath(cfg config.WasmExecutorConfig, hash string) string {
+	return filepath.Join(cfg.ModuleCacheDir, hash+".wasm")
+}
+
+// moduleHash 计算模块 sha256
+func moduleHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// isHexHash 判断是否为 64 位十六进制 sha256
+func isHexHash(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
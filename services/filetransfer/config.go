@@ -0,0 +1,80 @@
+package filetransfer
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// Root 允许读写的目录根
+type Root struct {
+	// 请求中使用的名称
+	Name string `yaml:"name"`
+	// 目录绝对路径
+	Path string `yaml:"path"`
+	// 是否允许写入
+	Writable bool `yaml:"writable"`
+}
+
+// FileTransferConfig 文件传输配置
+type FileTransferConfig struct {
+	// 目录根列表
+	Roots []Root `yaml:"roots"`
+	// 单个文件最大字节数，0 表示不限制
+	MaxFileBytes int64 `yaml:"maxFileBytes"`
+	// GetFile 每个分片的字节数
+	ChunkSize int `yaml:"chunkSize"`
+	// 是否允许设置属主与属组
+	AllowChown bool `yaml:"allowChown"`
+}
+
+// Config 文件传输配置文件结构
+type Config struct {
+	FileTransfer FileTransferConfig `yaml:"filetransfer"`
+}
+
+var (
+	globalConfig Config
+	rootMap      map[string]Root
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "filetransfer.yaml"), &globalConfig); err != nil {
+			panic("loadConfig filetransfer.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if globalConfig.FileTransfer.ChunkSize <= 0 {
+			globalConfig.FileTransfer.ChunkSize = 64 * 1024
+		}
+
+		rootMap = make(map[string]Root, len(globalConfig.FileTransfer.Roots))
+		for _, root := range globalConfig.FileTransfer.Roots {
+			if root.Name == "" || !filepath.IsAbs(root.Path) {
+				panic(fmt.Sprintf("loadConfig filetransfer.yaml err: invalid root %q", root.Name))
+			}
+			root.Path = filepath.Clean(root.Path)
+			rootMap[root.Name] = root
+		}
+	})
+}
+
+// GetFileTransferConfig 获取文件传输配置
+func GetFileTransferConfig() FileTransferConfig {
+	lazyLoadConfig()
+	return globalConfig.FileTransfer
+}
+
+// GetRoot 按名称获取目录根
+func GetRoot(name string) (Root, bool) {
+	lazyLoadConfig()
+	root, ok := rootMap[name]
+	return root, ok
+}
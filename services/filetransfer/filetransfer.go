@@ -0,0 +1,270 @@
+package filetransfer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"goumang-worker/services/internal/pathguard"
+	"goumang-worker/services/pb"
+	"hash"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownRoot 目录根未配置
+	ErrUnknownRoot = errors.New("unknown root")
+	// ErrReadOnly 目录根不允许写入
+	ErrReadOnly = errors.New("root is read-only")
+	// ErrInvalidPath 路径非法（绝对路径或越出目录根）
+	ErrInvalidPath = errors.New("invalid file path")
+	// ErrInvalidMode 权限位非法
+	ErrInvalidMode = errors.New("invalid file mode")
+	// ErrNotFound 文件或父目录不存在
+	ErrNotFound = errors.New("file not found")
+	// ErrTooLarge 文件超出大小限制
+	ErrTooLarge = errors.New("file too large")
+	// ErrChecksum 大小或 sha256 与声明不一致
+	ErrChecksum = errors.New("checksum mismatch")
+	// ErrChownDenied 未允许设置属主
+	ErrChownDenied = errors.New("chown is not allowed")
+)
+
+// defaultMode 未指定权限位时使用的权限
+const defaultMode = 0o644
+
+// Upload 一次文件上传，内容先写入同目录临时文件，提交时原子重命名
+type Upload struct {
+	header   *pb.FileHeader
+	target   string
+	mode     os.FileMode
+	uid, gid int
+	maxBytes int64
+	tmp      *os.File
+	hash     hash.Hash
+	size     int64
+}
+
+// Create 校验上传头并创建临时文件
+func Create(header *pb.FileHeader) (*Upload, error) {
+	cfg := GetFileTransferConfig()
+	root, ok := GetRoot(header.Root)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownRoot, header.Root)
+	}
+	if !root.Writable {
+		return nil, fmt.Errorf("%w: %q", ErrReadOnly, header.Root)
+	}
+
+	mode := os.FileMode(header.Mode)
+	if mode == 0 {
+		mode = defaultMode
+	}
+	// 不允许 setuid/setgid/sticky 位
+	if mode&^os.ModePerm != 0 {
+		return nil, fmt.Errorf("%w: %#o", ErrInvalidMode, header.Mode)
+	}
+	uid, gid, err := lookupOwner(cfg, header.Owner, header.Group)
+	if err != nil {
+		return nil, err
+	}
+	if header.Size < 0 || (cfg.MaxFileBytes > 0 && header.Size > cfg.MaxFileBytes) {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTooLarge, header.Size)
+	}
+
+	target, err := resolveWrite(root, header.Path, header.Mkdirs)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".goumang-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Upload{
+		header:   header,
+		target:   target,
+		mode:     mode,
+		uid:      uid,
+		gid:      gid,
+		maxBytes: cfg.MaxFileBytes,
+		tmp:      tmp,
+		hash:     sha256.New(),
+	}, nil
+}
+
+// Write 追加一个内容分片
+func (u *Upload) Write(data []byte) error {
+	u.size += int64(len(data))
+	if u.maxBytes > 0 && u.size > u.maxBytes {
+		return fmt.Errorf("%w: exceeds %d bytes", ErrTooLarge, u.maxBytes)
+	}
+	if u.header.Size > 0 && u.size > u.header.Size {
+		return fmt.Errorf("%w: more than %d bytes received", ErrChecksum, u.header.Size)
+	}
+	if _, err := u.tmp.Write(data); err != nil {
+		return err
+	}
+	u.hash.Write(data)
+	return nil
+}
+
+// Commit 校验大小与哈希，设置权限与属主后原子替换目标文件
+func (u *Upload) Commit() (*pb.PutFileResponse, error) {
+	sum := hex.EncodeToString(u.hash.Sum(nil))
+	if u.header.Size > 0 && u.size != u.header.Size {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", ErrChecksum, u.header.Size, u.size)
+	}
+	if u.header.Sha256 != "" && !strings.EqualFold(u.header.Sha256, sum) {
+		return nil, fmt.Errorf("%w: expected sha256 %s, got %s", ErrChecksum, u.header.Sha256, sum)
+	}
+
+	if err := u.tmp.Chmod(u.mode); err != nil {
+		return nil, err
+	}
+	if u.uid >= 0 || u.gid >= 0 {
+		if err := u.tmp.Chown(u.uid, u.gid); err != nil {
+			return nil, err
+		}
+	}
+	if err := u.tmp.Sync(); err != nil {
+		return nil, err
+	}
+	if err := u.tmp.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(u.tmp.Name(), u.target); err != nil {
+		return nil, err
+	}
+
+	return &pb.PutFileResponse{
+		Path:   u.header.Path,
+		Size:   u.size,
+		Sha256: sum,
+	}, nil
+}
+
+// Abort 放弃上传并删除临时文件，Commit 成功后调用无副作用
+func (u *Upload) Abort() {
+	_ = u.tmp.Close()
+	_ = os.Remove(u.tmp.Name())
+}
+
+// Open 打开目录根内的文件用于读取
+func Open(rootName, filePath string) (*os.File, error) {
+	root, ok := GetRoot(rootName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownRoot, rootName)
+	}
+	rel, ok := pathguard.CleanRelPath(filePath, false)
+	if !ok {
+		return nil, ErrInvalidPath
+	}
+	realRoot, err := filepath.EvalSymlinks(root.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析符号链接，防止越出目录根
+	realPath, err := filepath.EvalSymlinks(filepath.Join(realRoot, rel))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if !pathguard.IsWithin(realRoot, realPath) {
+		return nil, ErrInvalidPath
+	}
+	info, err := os.Stat(realPath)
+	if err != nil || !info.Mode().IsRegular() {
+		return nil, ErrNotFound
+	}
+	return os.Open(realPath)
+}
+
+// resolveWrite 解析写入目标路径，父目录必须位于目录根内
+func resolveWrite(root Root, filePath string, mkdirs bool) (string, error) {
+	rel, ok := pathguard.CleanRelPath(filePath, false)
+	if !ok {
+		return "", ErrInvalidPath
+	}
+	realRoot, err := filepath.EvalSymlinks(root.Path)
+	if err != nil {
+		return "", err
+	}
+	parent := filepath.Dir(filepath.Join(realRoot, rel))
+
+	// 先校验已存在的最近祖先目录，再在其下创建缺失的目录，避免经由符号链接在目录根外建目录
+	ancestor := parent
+	for {
+		if _, errL := os.Lstat(ancestor); errL == nil {
+			break
+		}
+		ancestor = filepath.Dir(ancestor)
+	}
+	realAncestor, err := filepath.EvalSymlinks(ancestor)
+	if err != nil {
+		return "", err
+	}
+	if !pathguard.IsWithin(realRoot, realAncestor) {
+		return "", ErrInvalidPath
+	}
+	if ancestor != parent {
+		if !mkdirs {
+			return "", fmt.Errorf("%w: parent directory of %q", ErrNotFound, filePath)
+		}
+		missing, _ := filepath.Rel(ancestor, parent)
+		if err = os.MkdirAll(filepath.Join(realAncestor, missing), 0o755); err != nil {
+			return "", err
+		}
+	}
+
+	realParent, err := filepath.EvalSymlinks(parent)
+	if err != nil {
+		return "", err
+	}
+	if !pathguard.IsWithin(realRoot, realParent) {
+		return "", ErrInvalidPath
+	}
+	target := filepath.Join(realParent, filepath.Base(rel))
+	if info, errS := os.Lstat(target); errS == nil && info.IsDir() {
+		return "", fmt.Errorf("%w: %q is a directory", ErrInvalidPath, filePath)
+	}
+	return target, nil
+}
+
+// lookupOwner 解析属主与属组，-1 表示不修改
+func lookupOwner(cfg FileTransferConfig, owner, group string) (uid, gid int, err error) {
+	uid, gid = -1, -1
+	if owner == "" && group == "" {
+		return uid, gid, nil
+	}
+	if !cfg.AllowChown {
+		return uid, gid, ErrChownDenied
+	}
+
+	if owner != "" {
+		if uid, err = strconv.Atoi(owner); err != nil {
+			u, errL := user.Lookup(owner)
+			if errL != nil {
+				return -1, -1, fmt.Errorf("lookup owner %q failed: %w", owner, errL)
+			}
+			uid, _ = strconv.Atoi(u.Uid)
+		}
+	}
+	if group != "" {
+		if gid, err = strconv.Atoi(group); err != nil {
+			g, errL := user.LookupGroup(group)
+			if errL != nil {
+				return -1, -1, fmt.Errorf("lookup group %q failed: %w", group, errL)
+			}
+			gid, _ = strconv.Atoi(g.Gid)
+		}
+	}
+	return uid, gid, nil
+}
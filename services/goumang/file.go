@@ -0,0 +1,157 @@
+package goumang
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"goumang-worker/services/filetransfer"
+	"goumang-worker/services/pb"
+	"goumang-worker/services/signing"
+	"io"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PutFile 分片上传文件到配置的目录根，校验通过后原子替换目标文件
+func (s *Server) PutFile(stream pb.Task_PutFileServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("receive header failed: %v", err))
+	}
+	header := first.GetHeader()
+	if header == nil {
+		return status.Error(codes.InvalidArgument, "first message must be a file header")
+	}
+
+	// 校验签名，签名覆盖内容哈希与权限属主，因此启用签名时必须声明 sha256
+	if signing.IsEnabled() {
+		if header.Sha256 == "" {
+			return status.Error(codes.PermissionDenied, "sha256 is required for signed uploads")
+		}
+		payload := signing.FilePayload(header)
+		if errV := signing.VerifyPayload(payload, header.KeyId, header.Signature); errV != nil {
+			logit.Context(stream.Context()).WarnW(
+				"logType", "file signature rejected",
+				"reason", errV.Error(),
+				"root", header.Root,
+				"path", header.Path,
+			)
+			return status.Error(codes.PermissionDenied, errV.Error())
+		}
+	}
+
+	upload, err := filetransfer.Create(header)
+	if err != nil {
+		return fileError("create file failed", err)
+	}
+	defer upload.Abort()
+
+	for {
+		req, errR := stream.Recv()
+		if errR == io.EOF {
+			break
+		}
+		if errR != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("receive chunk failed: %v", errR))
+		}
+		if req.GetHeader() != nil {
+			return status.Error(codes.InvalidArgument, "unexpected file header")
+		}
+		if err = upload.Write(req.GetData()); err != nil {
+			return fileError("write file failed", err)
+		}
+	}
+
+	resp, err := upload.Commit()
+	if err != nil {
+		return fileError("commit file failed", err)
+	}
+	logit.Context(stream.Context()).InfoW(
+		"logType", "file uploaded",
+		"root", header.Root,
+		"path", resp.Path,
+		"size", resp.Size,
+		"sha256", resp.Sha256,
+	)
+	return stream.SendAndClose(resp)
+}
+
+// GetFile 分片读取配置目录根内的文件，支持 offset/length，最后一个分片携带读取范围的 sha256
+func (s *Server) GetFile(req *pb.GetFileRequest, stream pb.Task_GetFileServer) error {
+	if req.Offset < 0 || req.Length < 0 {
+		return status.Error(codes.InvalidArgument, "negative offset or length")
+	}
+
+	file, err := filetransfer.Open(req.Root, req.Path)
+	if err != nil {
+		return fileError("open file failed", err)
+	}
+	defer func() {
+		if errC := file.Close(); errC != nil {
+			logit.Context(stream.Context()).WarnW("file.Close.Err", errC)
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("stat file failed: %v", err))
+	}
+	fileSize := info.Size()
+	if req.Offset > fileSize {
+		return status.Error(codes.OutOfRange, fmt.Sprintf("offset %d beyond file size %d", req.Offset, fileSize))
+	}
+	offset := req.Offset
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("seek file failed: %v", err))
+	}
+
+	var reader io.Reader = file
+	if req.Length > 0 {
+		reader = io.LimitReader(file, req.Length)
+	}
+
+	hash := sha256.New()
+	buf := make([]byte, filetransfer.GetFileTransferConfig().ChunkSize)
+	for {
+		n, errR := io.ReadFull(reader, buf)
+		eof := errR == io.EOF || errR == io.ErrUnexpectedEOF
+		if errR != nil && !eof {
+			return status.Error(codes.Internal, fmt.Sprintf("read file failed: %v", errR))
+		}
+		hash.Write(buf[:n])
+
+		chunk := &pb.FileChunk{Data: buf[:n], Offset: offset, FileSize: fileSize, Eof: eof}
+		if eof {
+			chunk.Sha256 = hex.EncodeToString(hash.Sum(nil))
+		}
+		if errS := stream.Send(chunk); errS != nil {
+			return errS
+		}
+		if eof {
+			return nil
+		}
+		offset += int64(n)
+	}
+}
+
+// fileError 转换文件传输错误为 gRPC 状态
+func fileError(msg string, err error) error {
+	switch {
+	case errors.Is(err, filetransfer.ErrUnknownRoot), errors.Is(err, filetransfer.ErrReadOnly),
+		errors.Is(err, filetransfer.ErrChownDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, filetransfer.ErrInvalidPath), errors.Is(err, filetransfer.ErrInvalidMode):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, filetransfer.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, filetransfer.ErrTooLarge):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, filetransfer.ErrChecksum):
+		return status.Error(codes.DataLoss, err.Error())
+	default:
+		return status.Error(codes.Internal, fmt.Sprintf("%s: %v", msg, err))
+	}
+}
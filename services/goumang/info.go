@@ -0,0 +1,92 @@
+package goumang
+
+import (
+	"context"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/middleware"
+	mwconfig "goumang-worker/services/executor/middleware/config"
+	shellconfig "goumang-worker/services/executor/shell/config"
+	"goumang-worker/services/pb"
+	"goumang-worker/services/signing"
+	"goumang-worker/services/worker"
+	"runtime"
+	"time"
+)
+
+// GetWorkerInfo 返回 worker 的版本、能力、安全设置、限制与当前负载，供调度端在派发任务前判断
+func (s *Server) GetWorkerInfo(_ context.Context, _ *pb.GetWorkerInfoRequest) (*pb.WorkerInfo, error) {
+	build := worker.BuildInfo()
+	info := &pb.WorkerInfo{
+		Version: worker.Version,
+		Build: &pb.BuildInfo{
+			GoVersion: build.GoVersion,
+			Revision:  build.Revision,
+			BuildTime: build.Time,
+			Modified:  build.Modified,
+		},
+		Hostname: worker.Hostname(),
+		Labels:   worker.Labels(),
+		Security: securitySettings(),
+		Limits:   workerLimits(),
+		Load:     s.workerLoad(),
+	}
+	for _, m := range executor.SupportedMethods() {
+		info.Methods = append(info.Methods, &pb.SupportedMethod{Name: m.Name, ParamsVersion: m.Version})
+	}
+	return info, nil
+}
+
+// securitySettings 当前生效的命令校验与签名设置
+func securitySettings() *pb.SecuritySettings {
+	security := shellconfig.GetSecurityConfig()
+	return &pb.SecuritySettings{
+		ValidationEnabled: security.EnableValidation,
+		AllowPipes:        security.CommandParsing.AllowPipes,
+		AllowRedirection:  security.CommandParsing.AllowRedirection,
+		AllowChaining:     security.CommandParsing.AllowChaining,
+		SigningRequired:   signing.IsEnabled(),
+	}
+}
+
+// workerLimits 任务超时限制，以及 middleware.yaml 中 concurrency、timeout 中间件的配置
+func workerLimits() *pb.WorkerLimits {
+	limits := &pb.WorkerLimits{
+		DefaultTimeoutSec:   int32(defaultTimeoutMinutes * time.Minute / time.Second),
+		MaxTimeoutSec:       int32(maxTimeoutMinutes * time.Minute / time.Second),
+		MethodMaxConcurrent: make(map[string]int32),
+		MethodTimeoutSec:    make(map[string]int32),
+	}
+	for _, entry := range mwconfig.GetMiddlewareConfig().Chain {
+		switch entry.Name {
+		case "concurrency":
+			limits.MaxConcurrent = int32(max(entry.MaxConcurrent, 0))
+			for _, m := range entry.Methods {
+				limits.MethodMaxConcurrent[m.Name] = int32(max(m.MaxConcurrent, 0))
+			}
+		case "timeout":
+			limits.MethodDefaultTimeoutSec = int32(max(entry.TimeoutSec, 0))
+			for _, m := range entry.Methods {
+				limits.MethodTimeoutSec[m.Name] = int32(max(m.TimeoutSec, 0))
+			}
+		}
+	}
+	return limits
+}
+
+// workerLoad 当前负载，按方法的统计来自 metrics 中间件
+func (s *Server) workerLoad() *pb.WorkerLoad {
+	load := &pb.WorkerLoad{
+		RunningTasks: s.running.Load(),
+		NumCpu:       int32(runtime.NumCPU()),
+		Methods:      make(map[string]*pb.MethodLoad),
+	}
+	for method, stats := range middleware.Snapshot() {
+		load.Methods[method] = &pb.MethodLoad{
+			Running:         stats.Running,
+			Total:           stats.Total,
+			Failed:          stats.Failed,
+			TotalDurationMs: stats.TotalDurationMs,
+		}
+	}
+	return load
+}
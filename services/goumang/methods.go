@@ -0,0 +1,34 @@
+package goumang
+
+import (
+	"context"
+	"encoding/json"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DescribeMethods 返回支持的方法及其参数 Schema，供调度端渲染参数表单
+func (s *Server) DescribeMethods(_ context.Context, _ *pb.DescribeMethodsRequest) (*pb.DescribeMethodsResponse, error) {
+	descriptions := executor.DescribeMethods()
+	resp := &pb.DescribeMethodsResponse{Methods: make([]*pb.MethodInfo, 0, len(descriptions))}
+	for _, desc := range descriptions {
+		info := &pb.MethodInfo{
+			Method:        executor.MethodEnum(desc.Name),
+			Name:          desc.Name,
+			ParamsVersion: desc.Version,
+			Description:   desc.Description,
+		}
+		if desc.Schema != nil {
+			data, err := json.Marshal(desc.Schema)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			info.ParamsSchema = string(data)
+		}
+		resp.Methods = append(resp.Methods, info)
+	}
+	return resp, nil
+}
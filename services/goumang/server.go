@@ -2,14 +2,29 @@ package goumang
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"goumang-worker/services/artifact"
 	"goumang-worker/services/executor"
+	"goumang-worker/services/executor/middleware"
 	"goumang-worker/services/pb"
+	"goumang-worker/services/signing"
+	"io"
+	"sync/atomic"
 	"time"
 
 	// 导入执行器包以触发自动注册
+	_ "goumang-worker/services/executor/archive"
+	_ "goumang-worker/services/executor/dag"
+	_ "goumang-worker/services/executor/grpccall"
+	_ "goumang-worker/services/executor/httpcall"
+	_ "goumang-worker/services/executor/pipeline"
 	_ "goumang-worker/services/executor/shell"
+	_ "goumang-worker/services/executor/shinterp"
+	_ "goumang-worker/services/executor/sqlexec"
+	_ "goumang-worker/services/executor/wasm"
 
+	"github.com/bpcoder16/Chestnut/v2/logit"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -22,10 +37,13 @@ const (
 
 type Server struct {
 	pb.UnimplementedTaskServer
+	// 正在执行的 Run 调用数
+	running atomic.Int32
 }
 
-// NewServer 创建服务器
+// NewServer 创建服务器，并按配置挂载执行中间件
 func NewServer() *Server {
+	middleware.Setup()
 	return &Server{}
 }
 
@@ -34,26 +52,102 @@ func (s *Server) RegisterService(serviceRegistrar grpc.ServiceRegistrar) {
 }
 
 func (s *Server) Run(req *pb.TaskRequest, stream pb.Task_RunServer) error {
+	return s.Execute(stream.Context(), req, executor.NewStreamSink(stream))
+}
+
+// Execute 校验并执行任务请求，输出写入 sink；入站 Run 与拉取模式共用
+func (s *Server) Execute(ctx context.Context, req *pb.TaskRequest, sink executor.Sink) error {
+	s.running.Add(1)
+	defer s.running.Add(-1)
+
 	timeout := s.getTimeout(req.Timeout)
 	if timeout > maxTimeoutMinutes*time.Minute {
 		timeout = maxTimeoutMinutes * time.Minute
 	}
-	ctx, cancel := context.WithTimeout(stream.Context(), timeout)
+
+	// 校验签名，未通过时不创建执行器
+	if signing.IsEnabled() {
+		if err := signing.Verify(req); err != nil {
+			logit.Context(ctx).WarnW(
+				"logType", "task signature rejected",
+				"reason", err.Error(),
+				"runTaskId", req.RunTaskId,
+			)
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+
+	if err := artifact.ValidatePatterns(req.RunTaskId, req.Artifacts); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
+	ctx = executor.WithTaskInfo(ctx, &executor.TaskInfo{
+		RunTaskID: req.RunTaskId,
+		Artifacts: req.Artifacts,
+	})
 
 	var err error
 
-	// 使用工厂创建执行器
-	exec, createErr := executor.CreateExecutor(req.Method)
+	// 使用工厂按方法名创建执行器
+	method := executor.RequestMethod(req)
+	exec, createErr := executor.CreateExecutor(method)
 	if createErr != nil {
-		err = status.Error(codes.InvalidArgument, fmt.Sprintf("unsupported method %s: %v", req.Method.String(), createErr))
-	} else {
-		err = exec.Execute(ctx, req.MethodParams, stream)
+		err = status.Error(codes.InvalidArgument, fmt.Sprintf("unsupported method %s: %v", method, createErr))
+	} else if err = executor.ValidateParams(method, req.ParamsVersion, req.MethodParams); err == nil {
+		err = exec.Execute(ctx, req.MethodParams, sink)
 	}
 
 	return err
 }
 
+// FetchArtifact 分片下载已收集的任务产物
+func (s *Server) FetchArtifact(req *pb.FetchArtifactRequest, stream pb.Task_FetchArtifactServer) error {
+	if req.Offset < 0 {
+		return status.Error(codes.InvalidArgument, "negative offset")
+	}
+
+	file, err := artifact.Open(req.RunTaskId, req.Path)
+	if err != nil {
+		switch {
+		case errors.Is(err, artifact.ErrInvalidPath):
+			return status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, artifact.ErrNotFound):
+			return status.Error(codes.NotFound, err.Error())
+		default:
+			return status.Error(codes.Internal, fmt.Sprintf("open artifact failed: %v", err))
+		}
+	}
+	defer func() {
+		if errC := file.Close(); errC != nil {
+			logit.Context(stream.Context()).WarnW("artifact.Close.Err", errC)
+		}
+	}()
+
+	offset := req.Offset
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("seek artifact failed: %v", err))
+	}
+
+	buf := make([]byte, artifact.GetArtifactConfig().ChunkSize)
+	for {
+		n, errR := file.Read(buf)
+		if n > 0 {
+			if errS := stream.Send(&pb.ArtifactChunk{Data: buf[:n], Offset: offset}); errS != nil {
+				return errS
+			}
+			offset += int64(n)
+		}
+		if errR == io.EOF {
+			return nil
+		}
+		if errR != nil {
+			return status.Error(codes.Internal, fmt.Sprintf("read artifact failed: %v", errR))
+		}
+	}
+}
+
 func (s *Server) getTimeout(timeoutSec int32) time.Duration {
 	if timeoutSec <= 0 {
 		return defaultTimeoutMinutes * time.Minute
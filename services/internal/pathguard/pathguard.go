@@ -0,0 +1,63 @@
+// Package pathguard 路径越界检查，供文件传输、产物、归档与解释器等按目录根限制访问的模块共用
+package pathguard
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// CleanRelPath 清理请求中的相对路径，拒绝空路径、绝对路径与 ".." 越界
+// allowRoot 为 true 时允许 "." 或空路径表示根目录本身
+func CleanRelPath(p string, allowRoot bool) (string, bool) {
+	if p == "" && allowRoot {
+		p = "."
+	}
+	if p == "" || filepath.IsAbs(p) || filepath.VolumeName(p) != "" {
+		return "", false
+	}
+	cleaned := filepath.Clean(p)
+	if (cleaned == "." && !allowRoot) || escapes(cleaned) {
+		return "", false
+	}
+	return cleaned, true
+}
+
+// CleanEntryName 清理归档条目名，拒绝绝对路径与 ".." 越界（zip-slip），根目录条目返回空字符串
+// 条目名按 "/" 分隔，"\" 同样视为分隔符
+func CleanEntryName(name string) (string, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(name, "/") || filepath.VolumeName(name) != "" {
+		return "", false
+	}
+	cleaned := path.Clean(name)
+	if cleaned == "." {
+		return "", true
+	}
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+	return filepath.FromSlash(cleaned), true
+}
+
+// IsWithin 判断 target 是否位于 dir 之内（含 dir 本身），两者需为同一形式（均为绝对路径或均已解析符号链接）
+func IsWithin(dir, target string) bool {
+	rel, err := filepath.Rel(dir, target)
+	if err != nil {
+		return false
+	}
+	return !escapes(rel) && !filepath.IsAbs(rel)
+}
+
+// LinkWithin 判断相对符号链接的目标是否仍在 dir 内，绝对路径目标一律视为越界
+func LinkWithin(dir, linkPath, linkTarget string) bool {
+	if linkTarget == "" || filepath.IsAbs(linkTarget) {
+		return false
+	}
+	return IsWithin(dir, filepath.Join(filepath.Dir(linkPath), linkTarget))
+}
+
+// escapes 已清理的相对路径是否越出起点
+func escapes(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,95 @@
+package pathguard
+
+import "testing"
+
+func TestCleanRelPath(t *testing.T) {
+	tests := []struct {
+		path      string
+		allowRoot bool
+		want      string
+		ok        bool
+	}{
+		{"a/b.txt", false, "a/b.txt", true},
+		{"./a//b/../c", false, "a/c", true},
+		{"a/..", true, ".", true},
+		{"a/..", false, "", false},
+		{"", true, ".", true},
+		{"", false, "", false},
+		{".", false, "", false},
+		{"/etc/passwd", true, "", false},
+		{"..", true, "", false},
+		{"../a", true, "", false},
+		{"a/../../b", true, "", false},
+		{"..a/b", false, "..a/b", true},
+	}
+	for _, tt := range tests {
+		got, ok := CleanRelPath(tt.path, tt.allowRoot)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("CleanRelPath(%q, %v) = %q, %v; want %q, %v", tt.path, tt.allowRoot, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCleanEntryName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"dir/file", "dir/file", true},
+		{"dir/", "dir", true},
+		{"./", "", true},
+		{"a/../b", "b", true},
+		{"/etc/passwd", "", false},
+		{"../evil", "", false},
+		{"a/../../evil", "", false},
+		{"..\\evil", "", false},
+		{"dir\\file", "dir/file", true},
+		{"\\abs", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := CleanEntryName(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("CleanEntryName(%q) = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestIsWithin(t *testing.T) {
+	tests := []struct {
+		dir, target string
+		want        bool
+	}{
+		{"/work", "/work", true},
+		{"/work", "/work/a/b", true},
+		{"/work", "/work/../work/a", true},
+		{"/work", "/workspace/a", false},
+		{"/work", "/", false},
+		{"/work", "/etc/passwd", false},
+		{"/work", "work/a", false},
+	}
+	for _, tt := range tests {
+		if got := IsWithin(tt.dir, tt.target); got != tt.want {
+			t.Errorf("IsWithin(%q, %q) = %v, want %v", tt.dir, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestLinkWithin(t *testing.T) {
+	tests := []struct {
+		linkPath, linkTarget string
+		want                 bool
+	}{
+		{"/work/a/link", "b", true},
+		{"/work/a/link", "../b", true},
+		{"/work/a/link", "..", true},
+		{"/work/a/link", "../../etc", false},
+		{"/work/link", "/work/a", false},
+		{"/work/link", "", false},
+	}
+	for _, tt := range tests {
+		if got := LinkWithin("/work", tt.linkPath, tt.linkTarget); got != tt.want {
+			t.Errorf("LinkWithin(%q, %q) = %v, want %v", tt.linkPath, tt.linkTarget, got, tt.want)
+		}
+	}
+}
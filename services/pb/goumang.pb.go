@@ -21,19 +21,106 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type NodeStatus int32
+
+const (
+	NodeStatus_NODE_PENDING   NodeStatus = 0
+	NodeStatus_NODE_RUNNING   NodeStatus = 1
+	NodeStatus_NODE_SUCCEEDED NodeStatus = 2
+	NodeStatus_NODE_FAILED    NodeStatus = 3
+	NodeStatus_NODE_SKIPPED   NodeStatus = 4
+)
+
+// Enum value maps for NodeStatus.
+var (
+	NodeStatus_name = map[int32]string{
+		0: "NODE_PENDING",
+		1: "NODE_RUNNING",
+		2: "NODE_SUCCEEDED",
+		3: "NODE_FAILED",
+		4: "NODE_SKIPPED",
+	}
+	NodeStatus_value = map[string]int32{
+		"NODE_PENDING":   0,
+		"NODE_RUNNING":   1,
+		"NODE_SUCCEEDED": 2,
+		"NODE_FAILED":    3,
+		"NODE_SKIPPED":   4,
+	}
+)
+
+func (x NodeStatus) Enum() *NodeStatus {
+	p := new(NodeStatus)
+	*p = x
+	return p
+}
+
+func (x NodeStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NodeStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_goumang_proto_enumTypes[0].Descriptor()
+}
+
+func (NodeStatus) Type() protoreflect.EnumType {
+	return &file_proto_goumang_proto_enumTypes[0]
+}
+
+func (x NodeStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NodeStatus.Descriptor instead.
+func (NodeStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{0}
+}
+
 type Method int32
 
 const (
-	Method_SHELL Method = 0
+	Method_SHELL     Method = 0
+	Method_HTTP      Method = 1
+	Method_SH_INTERP Method = 2
+	Method_SCRIPT    Method = 3
+	Method_SQL       Method = 4
+	Method_GRPC_CALL Method = 5
+	Method_WASM      Method = 6
+	Method_ARCHIVE   Method = 7
+	Method_PIPELINE  Method = 8
+	Method_DAG       Method = 9
+	// a method outside this enum (e.g. provided by a plugin), the accompanying
+	// name / method_name field is authoritative; never valid in TaskRequest.method
+	Method_OTHER Method = 100
 )
 
 // Enum value maps for Method.
 var (
 	Method_name = map[int32]string{
-		0: "SHELL",
+		0:   "SHELL",
+		1:   "HTTP",
+		2:   "SH_INTERP",
+		3:   "SCRIPT",
+		4:   "SQL",
+		5:   "GRPC_CALL",
+		6:   "WASM",
+		7:   "ARCHIVE",
+		8:   "PIPELINE",
+		9:   "DAG",
+		100: "OTHER",
 	}
 	Method_value = map[string]int32{
-		"SHELL": 0,
+		"SHELL":     0,
+		"HTTP":      1,
+		"SH_INTERP": 2,
+		"SCRIPT":    3,
+		"SQL":       4,
+		"GRPC_CALL": 5,
+		"WASM":      6,
+		"ARCHIVE":   7,
+		"PIPELINE":  8,
+		"DAG":       9,
+		"OTHER":     100,
 	}
 )
 
@@ -48,11 +135,11 @@ func (x Method) String() string {
 }
 
 func (Method) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_goumang_proto_enumTypes[0].Descriptor()
+	return file_proto_goumang_proto_enumTypes[1].Descriptor()
 }
 
 func (Method) Type() protoreflect.EnumType {
-	return &file_proto_goumang_proto_enumTypes[0]
+	return &file_proto_goumang_proto_enumTypes[1]
 }
 
 func (x Method) Number() protoreflect.EnumNumber {
@@ -61,15 +148,25 @@ func (x Method) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Method.Descriptor instead.
 func (Method) EnumDescriptor() ([]byte, []int) {
-	return file_proto_goumang_proto_rawDescGZIP(), []int{0}
+	return file_proto_goumang_proto_rawDescGZIP(), []int{1}
 }
 
 type TaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Method        Method                 `protobuf:"varint,1,opt,name=method,proto3,enum=goumang.Method" json:"method,omitempty"`
-	MethodParams  string                 `protobuf:"bytes,2,opt,name=method_params,json=methodParams,proto3" json:"method_params,omitempty"`
-	Timeout       int32                  `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	RunTaskId     uint64                 `protobuf:"varint,4,opt,name=run_task_id,json=runTaskId,proto3" json:"run_task_id,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Method       Method                 `protobuf:"varint,1,opt,name=method,proto3,enum=goumang.Method" json:"method,omitempty"`
+	MethodParams string                 `protobuf:"bytes,2,opt,name=method_params,json=methodParams,proto3" json:"method_params,omitempty"`
+	Timeout      int32                  `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	RunTaskId    uint64                 `protobuf:"varint,4,opt,name=run_task_id,json=runTaskId,proto3" json:"run_task_id,omitempty"`
+	// glob patterns relative to the task work dir, requires a non-zero run_task_id;
+	// nothing is collected when the task has no work dir (e.g. SHELL without workspace)
+	Artifacts []string `protobuf:"bytes,5,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// ed25519 detached signature over "<method name>\n<method_params>"
+	Signature []byte `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	KeyId     string `protobuf:"bytes,7,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// expected params version, 0 skips the check
+	ParamsVersion uint32 `protobuf:"varint,8,opt,name=params_version,json=paramsVersion,proto3" json:"params_version,omitempty"`
+	// overrides method when set, resolved by name (e.g. methods provided by plugins)
+	MethodName    string `protobuf:"bytes,9,opt,name=method_name,json=methodName,proto3" json:"method_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -132,13 +229,53 @@ func (x *TaskRequest) GetRunTaskId() uint64 {
 	return 0
 }
 
+func (x *TaskRequest) GetArtifacts() []string {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *TaskRequest) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *TaskRequest) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *TaskRequest) GetParamsVersion() uint32 {
+	if x != nil {
+		return x.ParamsVersion
+	}
+	return 0
+}
+
+func (x *TaskRequest) GetMethodName() string {
+	if x != nil {
+		return x.MethodName
+	}
+	return ""
+}
+
 type TaskResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Content:
 	//
 	//	*TaskResponse_Output
 	//	*TaskResponse_Error
-	Content       isTaskResponse_Content `protobuf_oneof:"content"`
+	//	*TaskResponse_Result
+	//	*TaskResponse_Heartbeat
+	//	*TaskResponse_Event
+	Content isTaskResponse_Content `protobuf_oneof:"content"`
+	// DAG node that produced this response, empty outside DAG tasks
+	Node          string `protobuf:"bytes,6,opt,name=node,proto3" json:"node,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -198,6 +335,40 @@ func (x *TaskResponse) GetError() string {
 	return ""
 }
 
+func (x *TaskResponse) GetResult() *TaskResult {
+	if x != nil {
+		if x, ok := x.Content.(*TaskResponse_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *TaskResponse) GetHeartbeat() *Heartbeat {
+	if x != nil {
+		if x, ok := x.Content.(*TaskResponse_Heartbeat); ok {
+			return x.Heartbeat
+		}
+	}
+	return nil
+}
+
+func (x *TaskResponse) GetEvent() *TaskEvent {
+	if x != nil {
+		if x, ok := x.Content.(*TaskResponse_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *TaskResponse) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
 type isTaskResponse_Content interface {
 	isTaskResponse_Content()
 }
@@ -210,77 +381,3181 @@ type TaskResponse_Error struct {
 	Error string `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
 }
 
+type TaskResponse_Result struct {
+	Result *TaskResult `protobuf:"bytes,3,opt,name=result,proto3,oneof"`
+}
+
+type TaskResponse_Heartbeat struct {
+	Heartbeat *Heartbeat `protobuf:"bytes,4,opt,name=heartbeat,proto3,oneof"`
+}
+
+type TaskResponse_Event struct {
+	Event *TaskEvent `protobuf:"bytes,5,opt,name=event,proto3,oneof"`
+}
+
 func (*TaskResponse_Output) isTaskResponse_Content() {}
 
 func (*TaskResponse_Error) isTaskResponse_Content() {}
 
-var File_proto_goumang_proto protoreflect.FileDescriptor
+func (*TaskResponse_Result) isTaskResponse_Content() {}
 
-const file_proto_goumang_proto_rawDesc = "" +
-	"\n" +
-	"\x13proto/goumang.proto\x12\agoumang\"\x95\x01\n" +
-	"\vTaskRequest\x12'\n" +
-	"\x06method\x18\x01 \x01(\x0e2\x0f.goumang.MethodR\x06method\x12#\n" +
-	"\rmethod_params\x18\x02 \x01(\tR\fmethodParams\x12\x18\n" +
-	"\atimeout\x18\x03 \x01(\x05R\atimeout\x12\x1e\n" +
-	"\vrun_task_id\x18\x04 \x01(\x04R\trunTaskId\"K\n" +
-	"\fTaskResponse\x12\x18\n" +
-	"\x06output\x18\x01 \x01(\tH\x00R\x06output\x12\x16\n" +
-	"\x05error\x18\x02 \x01(\tH\x00R\x05errorB\t\n" +
-	"\acontent*\x13\n" +
-	"\x06Method\x12\t\n" +
-	"\x05SHELL\x10\x002<\n" +
-	"\x04Task\x124\n" +
-	"\x03Run\x12\x14.goumang.TaskRequest\x1a\x15.goumang.TaskResponse0\x01B\x1cZ\x1agoumang-worker/services/pbb\x06proto3"
+func (*TaskResponse_Heartbeat) isTaskResponse_Content() {}
 
-var (
-	file_proto_goumang_proto_rawDescOnce sync.Once
-	file_proto_goumang_proto_rawDescData []byte
-)
+func (*TaskResponse_Event) isTaskResponse_Content() {}
 
-func file_proto_goumang_proto_rawDescGZIP() []byte {
-	file_proto_goumang_proto_rawDescOnce.Do(func() {
-		file_proto_goumang_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_goumang_proto_rawDesc), len(file_proto_goumang_proto_rawDesc)))
-	})
-	return file_proto_goumang_proto_rawDescData
+type TaskResult struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TotalBytes     uint64                 `protobuf:"varint,1,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	ForwardedBytes uint64                 `protobuf:"varint,2,opt,name=forwarded_bytes,json=forwardedBytes,proto3" json:"forwarded_bytes,omitempty"`
+	TotalLines     uint64                 `protobuf:"varint,3,opt,name=total_lines,json=totalLines,proto3" json:"total_lines,omitempty"`
+	ForwardedLines uint64                 `protobuf:"varint,4,opt,name=forwarded_lines,json=forwardedLines,proto3" json:"forwarded_lines,omitempty"`
+	Truncated      bool                   `protobuf:"varint,5,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	Artifacts      []*Artifact            `protobuf:"bytes,6,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	WorkspaceBytes uint64                 `protobuf:"varint,7,opt,name=workspace_bytes,json=workspaceBytes,proto3" json:"workspace_bytes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-var file_proto_goumang_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_goumang_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
-var file_proto_goumang_proto_goTypes = []any{
-	(Method)(0),          // 0: goumang.Method
-	(*TaskRequest)(nil),  // 1: goumang.TaskRequest
-	(*TaskResponse)(nil), // 2: goumang.TaskResponse
+func (x *TaskResult) Reset() {
+	*x = TaskResult{}
+	mi := &file_proto_goumang_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
-var file_proto_goumang_proto_depIdxs = []int32{
-	0, // 0: goumang.TaskRequest.method:type_name -> goumang.Method
-	1, // 1: goumang.Task.Run:input_type -> goumang.TaskRequest
-	2, // 2: goumang.Task.Run:output_type -> goumang.TaskResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+func (x *TaskResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_proto_goumang_proto_init() }
-func file_proto_goumang_proto_init() {
-	if File_proto_goumang_proto != nil {
-		return
+func (*TaskResult) ProtoMessage() {}
+
+func (x *TaskResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	file_proto_goumang_proto_msgTypes[1].OneofWrappers = []any{
-		(*TaskResponse_Output)(nil),
-		(*TaskResponse_Error)(nil),
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskResult.ProtoReflect.Descriptor instead.
+func (*TaskResult) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TaskResult) GetTotalBytes() uint64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *TaskResult) GetForwardedBytes() uint64 {
+	if x != nil {
+		return x.ForwardedBytes
+	}
+	return 0
+}
+
+func (x *TaskResult) GetTotalLines() uint64 {
+	if x != nil {
+		return x.TotalLines
+	}
+	return 0
+}
+
+func (x *TaskResult) GetForwardedLines() uint64 {
+	if x != nil {
+		return x.ForwardedLines
+	}
+	return 0
+}
+
+func (x *TaskResult) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *TaskResult) GetArtifacts() []*Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *TaskResult) GetWorkspaceBytes() uint64 {
+	if x != nil {
+		return x.WorkspaceBytes
+	}
+	return 0
+}
+
+type Artifact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Size          uint64                 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	mi := &file_proto_goumang_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artifact) ProtoMessage() {}
+
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Artifact) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Artifact) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Artifact) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type FetchArtifactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunTaskId     uint64                 `protobuf:"varint,1,opt,name=run_task_id,json=runTaskId,proto3" json:"run_task_id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Offset        int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchArtifactRequest) Reset() {
+	*x = FetchArtifactRequest{}
+	mi := &file_proto_goumang_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchArtifactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchArtifactRequest) ProtoMessage() {}
+
+func (x *FetchArtifactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchArtifactRequest.ProtoReflect.Descriptor instead.
+func (*FetchArtifactRequest) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FetchArtifactRequest) GetRunTaskId() uint64 {
+	if x != nil {
+		return x.RunTaskId
+	}
+	return 0
+}
+
+func (x *FetchArtifactRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FetchArtifactRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ArtifactChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArtifactChunk) Reset() {
+	*x = ArtifactChunk{}
+	mi := &file_proto_goumang_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArtifactChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArtifactChunk) ProtoMessage() {}
+
+func (x *ArtifactChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArtifactChunk.ProtoReflect.Descriptor instead.
+func (*ArtifactChunk) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ArtifactChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ArtifactChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// first message must carry the header, followed by data chunks in order
+type PutFileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Content:
+	//
+	//	*PutFileRequest_Header
+	//	*PutFileRequest_Data
+	Content       isPutFileRequest_Content `protobuf_oneof:"content"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutFileRequest) Reset() {
+	*x = PutFileRequest{}
+	mi := &file_proto_goumang_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutFileRequest) ProtoMessage() {}
+
+func (x *PutFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutFileRequest.ProtoReflect.Descriptor instead.
+func (*PutFileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PutFileRequest) GetContent() isPutFileRequest_Content {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *PutFileRequest) GetHeader() *FileHeader {
+	if x != nil {
+		if x, ok := x.Content.(*PutFileRequest_Header); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *PutFileRequest) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Content.(*PutFileRequest_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+type isPutFileRequest_Content interface {
+	isPutFileRequest_Content()
+}
+
+type PutFileRequest_Header struct {
+	Header *FileHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type PutFileRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*PutFileRequest_Header) isPutFileRequest_Content() {}
+
+func (*PutFileRequest_Data) isPutFileRequest_Content() {}
+
+type FileHeader struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Root  string                 `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Path  string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// permission bits, 0644 when zero
+	Mode uint32 `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	// user/group name or numeric id, unchanged when empty
+	Owner  string `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	Group  string `protobuf:"bytes,5,opt,name=group,proto3" json:"group,omitempty"`
+	Sha256 string `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	Size   int64  `protobuf:"varint,7,opt,name=size,proto3" json:"size,omitempty"`
+	Mkdirs bool   `protobuf:"varint,8,opt,name=mkdirs,proto3" json:"mkdirs,omitempty"`
+	// ed25519 detached signature over
+	// "PUT_FILE\n<root>\n<path>\n<sha256>\n<mode>\n<owner>\n<group>",
+	// mode as 4-digit octal of the field value (0000 when unset)
+	Signature     []byte `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+	KeyId         string `protobuf:"bytes,10,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileHeader) Reset() {
+	*x = FileHeader{}
+	mi := &file_proto_goumang_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileHeader) ProtoMessage() {}
+
+func (x *FileHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileHeader.ProtoReflect.Descriptor instead.
+func (*FileHeader) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FileHeader) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *FileHeader) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileHeader) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+func (x *FileHeader) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *FileHeader) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *FileHeader) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *FileHeader) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileHeader) GetMkdirs() bool {
+	if x != nil {
+		return x.Mkdirs
+	}
+	return false
+}
+
+func (x *FileHeader) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *FileHeader) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+type PutFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutFileResponse) Reset() {
+	*x = PutFileResponse{}
+	mi := &file_proto_goumang_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutFileResponse) ProtoMessage() {}
+
+func (x *PutFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutFileResponse.ProtoReflect.Descriptor instead.
+func (*PutFileResponse) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PutFileResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PutFileResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PutFileResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type GetFileRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Root   string                 `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Path   string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Offset int64                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// 0 reads to end of file
+	Length        int64 `protobuf:"varint,4,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFileRequest) Reset() {
+	*x = GetFileRequest{}
+	mi := &file_proto_goumang_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFileRequest) ProtoMessage() {}
+
+func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFileRequest.ProtoReflect.Descriptor instead.
+func (*GetFileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetFileRequest) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *GetFileRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GetFileRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetFileRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type FileChunk struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Data   []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Offset int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// set on the last chunk, sha256 covers the requested range
+	Eof           bool   `protobuf:"varint,3,opt,name=eof,proto3" json:"eof,omitempty"`
+	Sha256        string `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	FileSize      int64  `protobuf:"varint,5,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileChunk) Reset() {
+	*x = FileChunk{}
+	mi := &file_proto_goumang_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChunk) ProtoMessage() {}
+
+func (x *FileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FileChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *FileChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FileChunk) GetEof() bool {
+	if x != nil {
+		return x.Eof
+	}
+	return false
+}
+
+func (x *FileChunk) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *FileChunk) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+type Heartbeat struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ElapsedMs     int64                  `protobuf:"varint,1,opt,name=elapsed_ms,json=elapsedMs,proto3" json:"elapsed_ms,omitempty"`
+	Alive         bool                   `protobuf:"varint,2,opt,name=alive,proto3" json:"alive,omitempty"`
+	ProcessCount  int32                  `protobuf:"varint,3,opt,name=process_count,json=processCount,proto3" json:"process_count,omitempty"`
+	CpuSeconds    float64                `protobuf:"fixed64,4,opt,name=cpu_seconds,json=cpuSeconds,proto3" json:"cpu_seconds,omitempty"`
+	CpuPercent    float64                `protobuf:"fixed64,5,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	RssBytes      uint64                 `protobuf:"varint,6,opt,name=rss_bytes,json=rssBytes,proto3" json:"rss_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Heartbeat) Reset() {
+	*x = Heartbeat{}
+	mi := &file_proto_goumang_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Heartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heartbeat) ProtoMessage() {}
+
+func (x *Heartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heartbeat.ProtoReflect.Descriptor instead.
+func (*Heartbeat) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Heartbeat) GetElapsedMs() int64 {
+	if x != nil {
+		return x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *Heartbeat) GetAlive() bool {
+	if x != nil {
+		return x.Alive
+	}
+	return false
+}
+
+func (x *Heartbeat) GetProcessCount() int32 {
+	if x != nil {
+		return x.ProcessCount
+	}
+	return 0
+}
+
+func (x *Heartbeat) GetCpuSeconds() float64 {
+	if x != nil {
+		return x.CpuSeconds
+	}
+	return 0
+}
+
+func (x *Heartbeat) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *Heartbeat) GetRssBytes() uint64 {
+	if x != nil {
+		return x.RssBytes
+	}
+	return 0
+}
+
+type TaskEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*TaskEvent_Progress
+	//	*TaskEvent_Metrics
+	//	*TaskEvent_Status
+	//	*TaskEvent_HttpResponse
+	//	*TaskEvent_Columns
+	//	*TaskEvent_StepStart
+	//	*TaskEvent_StepEnd
+	//	*TaskEvent_NodeState
+	Event         isTaskEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_proto_goumang_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TaskEvent) GetEvent() isTaskEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetProgress() float64 {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_Progress); ok {
+			return x.Progress
+		}
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetMetrics() *Metrics {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_Metrics); ok {
+			return x.Metrics
+		}
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetStatus() string {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_Status); ok {
+			return x.Status
+		}
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetHttpResponse() *HttpResponse {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_HttpResponse); ok {
+			return x.HttpResponse
+		}
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetColumns() *Columns {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_Columns); ok {
+			return x.Columns
+		}
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetStepStart() *StepStart {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_StepStart); ok {
+			return x.StepStart
+		}
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetStepEnd() *StepEnd {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_StepEnd); ok {
+			return x.StepEnd
+		}
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetNodeState() *NodeState {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_NodeState); ok {
+			return x.NodeState
+		}
+	}
+	return nil
+}
+
+type isTaskEvent_Event interface {
+	isTaskEvent_Event()
+}
+
+type TaskEvent_Progress struct {
+	Progress float64 `protobuf:"fixed64,1,opt,name=progress,proto3,oneof"`
+}
+
+type TaskEvent_Metrics struct {
+	Metrics *Metrics `protobuf:"bytes,2,opt,name=metrics,proto3,oneof"`
+}
+
+type TaskEvent_Status struct {
+	Status string `protobuf:"bytes,3,opt,name=status,proto3,oneof"`
+}
+
+type TaskEvent_HttpResponse struct {
+	HttpResponse *HttpResponse `protobuf:"bytes,4,opt,name=http_response,json=httpResponse,proto3,oneof"`
+}
+
+type TaskEvent_Columns struct {
+	Columns *Columns `protobuf:"bytes,5,opt,name=columns,proto3,oneof"`
+}
+
+type TaskEvent_StepStart struct {
+	StepStart *StepStart `protobuf:"bytes,6,opt,name=step_start,json=stepStart,proto3,oneof"`
+}
+
+type TaskEvent_StepEnd struct {
+	StepEnd *StepEnd `protobuf:"bytes,7,opt,name=step_end,json=stepEnd,proto3,oneof"`
+}
+
+type TaskEvent_NodeState struct {
+	NodeState *NodeState `protobuf:"bytes,8,opt,name=node_state,json=nodeState,proto3,oneof"`
+}
+
+func (*TaskEvent_Progress) isTaskEvent_Event() {}
+
+func (*TaskEvent_Metrics) isTaskEvent_Event() {}
+
+func (*TaskEvent_Status) isTaskEvent_Event() {}
+
+func (*TaskEvent_HttpResponse) isTaskEvent_Event() {}
+
+func (*TaskEvent_Columns) isTaskEvent_Event() {}
+
+func (*TaskEvent_StepStart) isTaskEvent_Event() {}
+
+func (*TaskEvent_StepEnd) isTaskEvent_Event() {}
+
+func (*TaskEvent_NodeState) isTaskEvent_Event() {}
+
+type Metrics struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        map[string]string      `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Metrics) Reset() {
+	*x = Metrics{}
+	mi := &file_proto_goumang_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Metrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metrics) ProtoMessage() {}
+
+func (x *Metrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metrics.ProtoReflect.Descriptor instead.
+func (*Metrics) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Metrics) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type HttpResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	StatusCode int32                  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Headers    map[string]string      `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Attempt    int32                  `protobuf:"varint,3,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	// body is not UTF-8 text, each output line is an independently base64-encoded chunk
+	BodyBase64    bool `protobuf:"varint,4,opt,name=body_base64,json=bodyBase64,proto3" json:"body_base64,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HttpResponse) Reset() {
+	*x = HttpResponse{}
+	mi := &file_proto_goumang_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpResponse) ProtoMessage() {}
+
+func (x *HttpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpResponse.ProtoReflect.Descriptor instead.
+func (*HttpResponse) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HttpResponse) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *HttpResponse) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HttpResponse) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *HttpResponse) GetBodyBase64() bool {
+	if x != nil {
+		return x.BodyBase64
+	}
+	return false
+}
+
+type Columns struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	Types         []string               `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Columns) Reset() {
+	*x = Columns{}
+	mi := &file_proto_goumang_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Columns) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Columns) ProtoMessage() {}
+
+func (x *Columns) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Columns.ProtoReflect.Descriptor instead.
+func (*Columns) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Columns) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *Columns) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type StepStart struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Index int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// OTHER when method_name is not in the enum, method_name is authoritative
+	Method        Method `protobuf:"varint,3,opt,name=method,proto3,enum=goumang.Method" json:"method,omitempty"`
+	MethodName    string `protobuf:"bytes,4,opt,name=method_name,json=methodName,proto3" json:"method_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepStart) Reset() {
+	*x = StepStart{}
+	mi := &file_proto_goumang_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepStart) ProtoMessage() {}
+
+func (x *StepStart) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepStart.ProtoReflect.Descriptor instead.
+func (*StepStart) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StepStart) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *StepStart) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StepStart) GetMethod() Method {
+	if x != nil {
+		return x.Method
+	}
+	return Method_SHELL
+}
+
+func (x *StepStart) GetMethodName() string {
+	if x != nil {
+		return x.MethodName
+	}
+	return ""
+}
+
+type StepEnd struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Index int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// 0 on success, -1 when the failure carries no exit status
+	ExitCode      int32       `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Error         string      `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs    int64       `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Result        *TaskResult `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepEnd) Reset() {
+	*x = StepEnd{}
+	mi := &file_proto_goumang_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepEnd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepEnd) ProtoMessage() {}
+
+func (x *StepEnd) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepEnd.ProtoReflect.Descriptor instead.
+func (*StepEnd) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *StepEnd) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *StepEnd) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StepEnd) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *StepEnd) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *StepEnd) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *StepEnd) GetResult() *TaskResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type NodeState struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Node   string                 `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	Status NodeStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=goumang.NodeStatus" json:"status,omitempty"`
+	// set when the node has finished, same meaning as StepEnd
+	ExitCode      int32       `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Error         string      `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs    int64       `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Result        *TaskResult `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeState) Reset() {
+	*x = NodeState{}
+	mi := &file_proto_goumang_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeState) ProtoMessage() {}
+
+func (x *NodeState) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeState.ProtoReflect.Descriptor instead.
+func (*NodeState) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NodeState) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *NodeState) GetStatus() NodeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return NodeStatus_NODE_PENDING
+}
+
+func (x *NodeState) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *NodeState) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *NodeState) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *NodeState) GetResult() *TaskResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type DescribeMethodsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeMethodsRequest) Reset() {
+	*x = DescribeMethodsRequest{}
+	mi := &file_proto_goumang_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeMethodsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeMethodsRequest) ProtoMessage() {}
+
+func (x *DescribeMethodsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeMethodsRequest.ProtoReflect.Descriptor instead.
+func (*DescribeMethodsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{19}
+}
+
+type DescribeMethodsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Methods       []*MethodInfo          `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeMethodsResponse) Reset() {
+	*x = DescribeMethodsResponse{}
+	mi := &file_proto_goumang_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeMethodsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeMethodsResponse) ProtoMessage() {}
+
+func (x *DescribeMethodsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeMethodsResponse.ProtoReflect.Descriptor instead.
+func (*DescribeMethodsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DescribeMethodsResponse) GetMethods() []*MethodInfo {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+type MethodInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// OTHER when name is not in the enum, name is authoritative
+	Method        Method `protobuf:"varint,1,opt,name=method,proto3,enum=goumang.Method" json:"method,omitempty"`
+	ParamsVersion uint32 `protobuf:"varint,2,opt,name=params_version,json=paramsVersion,proto3" json:"params_version,omitempty"`
+	Description   string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// JSON Schema of method_params, root type "string" means plain text params
+	ParamsSchema  string `protobuf:"bytes,4,opt,name=params_schema,json=paramsSchema,proto3" json:"params_schema,omitempty"`
+	Name          string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MethodInfo) Reset() {
+	*x = MethodInfo{}
+	mi := &file_proto_goumang_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MethodInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodInfo) ProtoMessage() {}
+
+func (x *MethodInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodInfo.ProtoReflect.Descriptor instead.
+func (*MethodInfo) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MethodInfo) GetMethod() Method {
+	if x != nil {
+		return x.Method
+	}
+	return Method_SHELL
+}
+
+func (x *MethodInfo) GetParamsVersion() uint32 {
+	if x != nil {
+		return x.ParamsVersion
+	}
+	return 0
+}
+
+func (x *MethodInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MethodInfo) GetParamsSchema() string {
+	if x != nil {
+		return x.ParamsSchema
+	}
+	return ""
+}
+
+func (x *MethodInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetWorkerInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkerInfoRequest) Reset() {
+	*x = GetWorkerInfoRequest{}
+	mi := &file_proto_goumang_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkerInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkerInfoRequest) ProtoMessage() {}
+
+func (x *GetWorkerInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkerInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkerInfoRequest) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{22}
+}
+
+type WorkerInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Build         *BuildInfo             `protobuf:"bytes,2,opt,name=build,proto3" json:"build,omitempty"`
+	Hostname      string                 `protobuf:"bytes,3,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Methods       []*SupportedMethod     `protobuf:"bytes,5,rep,name=methods,proto3" json:"methods,omitempty"`
+	Security      *SecuritySettings      `protobuf:"bytes,6,opt,name=security,proto3" json:"security,omitempty"`
+	Limits        *WorkerLimits          `protobuf:"bytes,7,opt,name=limits,proto3" json:"limits,omitempty"`
+	Load          *WorkerLoad            `protobuf:"bytes,8,opt,name=load,proto3" json:"load,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerInfo) Reset() {
+	*x = WorkerInfo{}
+	mi := &file_proto_goumang_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerInfo) ProtoMessage() {}
+
+func (x *WorkerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerInfo.ProtoReflect.Descriptor instead.
+func (*WorkerInfo) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WorkerInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *WorkerInfo) GetBuild() *BuildInfo {
+	if x != nil {
+		return x.Build
+	}
+	return nil
+}
+
+func (x *WorkerInfo) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *WorkerInfo) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *WorkerInfo) GetMethods() []*SupportedMethod {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *WorkerInfo) GetSecurity() *SecuritySettings {
+	if x != nil {
+		return x.Security
+	}
+	return nil
+}
+
+func (x *WorkerInfo) GetLimits() *WorkerLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *WorkerInfo) GetLoad() *WorkerLoad {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
+type BuildInfo struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	GoVersion string                 `protobuf:"bytes,1,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	Revision  string                 `protobuf:"bytes,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	BuildTime string                 `protobuf:"bytes,3,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	// built from a working tree with uncommitted changes
+	Modified      bool `protobuf:"varint,4,opt,name=modified,proto3" json:"modified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildInfo) Reset() {
+	*x = BuildInfo{}
+	mi := &file_proto_goumang_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildInfo) ProtoMessage() {}
+
+func (x *BuildInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildInfo.ProtoReflect.Descriptor instead.
+func (*BuildInfo) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BuildInfo) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetRevision() string {
+	if x != nil {
+		return x.Revision
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetBuildTime() string {
+	if x != nil {
+		return x.BuildTime
+	}
+	return ""
+}
+
+func (x *BuildInfo) GetModified() bool {
+	if x != nil {
+		return x.Modified
+	}
+	return false
+}
+
+type SupportedMethod struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ParamsVersion uint32                 `protobuf:"varint,2,opt,name=params_version,json=paramsVersion,proto3" json:"params_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SupportedMethod) Reset() {
+	*x = SupportedMethod{}
+	mi := &file_proto_goumang_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SupportedMethod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SupportedMethod) ProtoMessage() {}
+
+func (x *SupportedMethod) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SupportedMethod.ProtoReflect.Descriptor instead.
+func (*SupportedMethod) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SupportedMethod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SupportedMethod) GetParamsVersion() uint32 {
+	if x != nil {
+		return x.ParamsVersion
+	}
+	return 0
+}
+
+type SecuritySettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// shell command validation
+	ValidationEnabled bool `protobuf:"varint,1,opt,name=validation_enabled,json=validationEnabled,proto3" json:"validation_enabled,omitempty"`
+	AllowPipes        bool `protobuf:"varint,2,opt,name=allow_pipes,json=allowPipes,proto3" json:"allow_pipes,omitempty"`
+	AllowRedirection  bool `protobuf:"varint,3,opt,name=allow_redirection,json=allowRedirection,proto3" json:"allow_redirection,omitempty"`
+	AllowChaining     bool `protobuf:"varint,4,opt,name=allow_chaining,json=allowChaining,proto3" json:"allow_chaining,omitempty"`
+	// task and file requests must be signed
+	SigningRequired bool `protobuf:"varint,5,opt,name=signing_required,json=signingRequired,proto3" json:"signing_required,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SecuritySettings) Reset() {
+	*x = SecuritySettings{}
+	mi := &file_proto_goumang_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecuritySettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecuritySettings) ProtoMessage() {}
+
+func (x *SecuritySettings) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecuritySettings.ProtoReflect.Descriptor instead.
+func (*SecuritySettings) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SecuritySettings) GetValidationEnabled() bool {
+	if x != nil {
+		return x.ValidationEnabled
+	}
+	return false
+}
+
+func (x *SecuritySettings) GetAllowPipes() bool {
+	if x != nil {
+		return x.AllowPipes
+	}
+	return false
+}
+
+func (x *SecuritySettings) GetAllowRedirection() bool {
+	if x != nil {
+		return x.AllowRedirection
+	}
+	return false
+}
+
+func (x *SecuritySettings) GetAllowChaining() bool {
+	if x != nil {
+		return x.AllowChaining
+	}
+	return false
+}
+
+func (x *SecuritySettings) GetSigningRequired() bool {
+	if x != nil {
+		return x.SigningRequired
+	}
+	return false
+}
+
+type WorkerLimits struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	DefaultTimeoutSec int32                  `protobuf:"varint,1,opt,name=default_timeout_sec,json=defaultTimeoutSec,proto3" json:"default_timeout_sec,omitempty"`
+	MaxTimeoutSec     int32                  `protobuf:"varint,2,opt,name=max_timeout_sec,json=maxTimeoutSec,proto3" json:"max_timeout_sec,omitempty"`
+	// 0 means unlimited
+	MaxConcurrent       int32            `protobuf:"varint,3,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	MethodMaxConcurrent map[string]int32 `protobuf:"bytes,4,rep,name=method_max_concurrent,json=methodMaxConcurrent,proto3" json:"method_max_concurrent,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// per-task timeout enforced by the timeout middleware, 0 means none
+	MethodDefaultTimeoutSec int32            `protobuf:"varint,5,opt,name=method_default_timeout_sec,json=methodDefaultTimeoutSec,proto3" json:"method_default_timeout_sec,omitempty"`
+	MethodTimeoutSec        map[string]int32 `protobuf:"bytes,6,rep,name=method_timeout_sec,json=methodTimeoutSec,proto3" json:"method_timeout_sec,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *WorkerLimits) Reset() {
+	*x = WorkerLimits{}
+	mi := &file_proto_goumang_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerLimits) ProtoMessage() {}
+
+func (x *WorkerLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerLimits.ProtoReflect.Descriptor instead.
+func (*WorkerLimits) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *WorkerLimits) GetDefaultTimeoutSec() int32 {
+	if x != nil {
+		return x.DefaultTimeoutSec
+	}
+	return 0
+}
+
+func (x *WorkerLimits) GetMaxTimeoutSec() int32 {
+	if x != nil {
+		return x.MaxTimeoutSec
+	}
+	return 0
+}
+
+func (x *WorkerLimits) GetMaxConcurrent() int32 {
+	if x != nil {
+		return x.MaxConcurrent
+	}
+	return 0
+}
+
+func (x *WorkerLimits) GetMethodMaxConcurrent() map[string]int32 {
+	if x != nil {
+		return x.MethodMaxConcurrent
+	}
+	return nil
+}
+
+func (x *WorkerLimits) GetMethodDefaultTimeoutSec() int32 {
+	if x != nil {
+		return x.MethodDefaultTimeoutSec
+	}
+	return 0
+}
+
+func (x *WorkerLimits) GetMethodTimeoutSec() map[string]int32 {
+	if x != nil {
+		return x.MethodTimeoutSec
+	}
+	return nil
+}
+
+type WorkerLoad struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Run calls in flight
+	RunningTasks int32 `protobuf:"varint,1,opt,name=running_tasks,json=runningTasks,proto3" json:"running_tasks,omitempty"`
+	NumCpu       int32 `protobuf:"varint,2,opt,name=num_cpu,json=numCpu,proto3" json:"num_cpu,omitempty"`
+	// per-method stats, empty unless the metrics middleware is enabled
+	Methods       map[string]*MethodLoad `protobuf:"bytes,3,rep,name=methods,proto3" json:"methods,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkerLoad) Reset() {
+	*x = WorkerLoad{}
+	mi := &file_proto_goumang_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkerLoad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerLoad) ProtoMessage() {}
+
+func (x *WorkerLoad) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerLoad.ProtoReflect.Descriptor instead.
+func (*WorkerLoad) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *WorkerLoad) GetRunningTasks() int32 {
+	if x != nil {
+		return x.RunningTasks
+	}
+	return 0
+}
+
+func (x *WorkerLoad) GetNumCpu() int32 {
+	if x != nil {
+		return x.NumCpu
+	}
+	return 0
+}
+
+func (x *WorkerLoad) GetMethods() map[string]*MethodLoad {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+type MethodLoad struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Running         int64                  `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	Total           int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Failed          int64                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	TotalDurationMs int64                  `protobuf:"varint,4,opt,name=total_duration_ms,json=totalDurationMs,proto3" json:"total_duration_ms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MethodLoad) Reset() {
+	*x = MethodLoad{}
+	mi := &file_proto_goumang_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MethodLoad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodLoad) ProtoMessage() {}
+
+func (x *MethodLoad) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodLoad.ProtoReflect.Descriptor instead.
+func (*MethodLoad) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MethodLoad) GetRunning() int64 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *MethodLoad) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *MethodLoad) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *MethodLoad) GetTotalDurationMs() int64 {
+	if x != nil {
+		return x.TotalDurationMs
+	}
+	return 0
+}
+
+type RegisterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// address the scheduler dials to reach the Task service
+	Address       string      `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Info          *WorkerInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterRequest) Reset() {
+	*x = RegisterRequest{}
+	mi := &file_proto_goumang_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterRequest) ProtoMessage() {}
+
+func (x *RegisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterRequest) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RegisterRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RegisterRequest) GetInfo() *WorkerInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type RegisterResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	// overrides the configured heartbeat interval when positive
+	HeartbeatIntervalSec int32 `protobuf:"varint,2,opt,name=heartbeat_interval_sec,json=heartbeatIntervalSec,proto3" json:"heartbeat_interval_sec,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RegisterResponse) Reset() {
+	*x = RegisterResponse{}
+	mi := &file_proto_goumang_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterResponse) ProtoMessage() {}
+
+func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterResponse) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RegisterResponse) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *RegisterResponse) GetHeartbeatIntervalSec() int32 {
+	if x != nil {
+		return x.HeartbeatIntervalSec
+	}
+	return 0
+}
+
+type HeartbeatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	Load          *WorkerLoad            `protobuf:"bytes,2,opt,name=load,proto3" json:"load,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatRequest) Reset() {
+	*x = HeartbeatRequest{}
+	mi := &file_proto_goumang_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRequest) ProtoMessage() {}
+
+func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
+func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *HeartbeatRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetLoad() *WorkerLoad {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
+type HeartbeatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeartbeatResponse) Reset() {
+	*x = HeartbeatResponse{}
+	mi := &file_proto_goumang_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeartbeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatResponse) ProtoMessage() {}
+
+func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
+func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{33}
+}
+
+type DeregisterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkerId      string                 `protobuf:"bytes,1,opt,name=worker_id,json=workerId,proto3" json:"worker_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterRequest) Reset() {
+	*x = DeregisterRequest{}
+	mi := &file_proto_goumang_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterRequest) ProtoMessage() {}
+
+func (x *DeregisterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterRequest.ProtoReflect.Descriptor instead.
+func (*DeregisterRequest) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeregisterRequest) GetWorkerId() string {
+	if x != nil {
+		return x.WorkerId
+	}
+	return ""
+}
+
+type DeregisterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeregisterResponse) Reset() {
+	*x = DeregisterResponse{}
+	mi := &file_proto_goumang_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeregisterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeregisterResponse) ProtoMessage() {}
+
+func (x *DeregisterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeregisterResponse.ProtoReflect.Descriptor instead.
+func (*DeregisterResponse) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{35}
+}
+
+type PullRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Content:
+	//
+	//	*PullRequest_Hello
+	//	*PullRequest_Credit
+	//	*PullRequest_Output
+	//	*PullRequest_Done
+	Content       isPullRequest_Content `protobuf_oneof:"content"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullRequest) Reset() {
+	*x = PullRequest{}
+	mi := &file_proto_goumang_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequest) ProtoMessage() {}
+
+func (x *PullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequest.ProtoReflect.Descriptor instead.
+func (*PullRequest) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *PullRequest) GetContent() isPullRequest_Content {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *PullRequest) GetHello() *WorkerInfo {
+	if x != nil {
+		if x, ok := x.Content.(*PullRequest_Hello); ok {
+			return x.Hello
+		}
+	}
+	return nil
+}
+
+func (x *PullRequest) GetCredit() int32 {
+	if x != nil {
+		if x, ok := x.Content.(*PullRequest_Credit); ok {
+			return x.Credit
+		}
+	}
+	return 0
+}
+
+func (x *PullRequest) GetOutput() *PullOutput {
+	if x != nil {
+		if x, ok := x.Content.(*PullRequest_Output); ok {
+			return x.Output
+		}
+	}
+	return nil
+}
+
+func (x *PullRequest) GetDone() *PullDone {
+	if x != nil {
+		if x, ok := x.Content.(*PullRequest_Done); ok {
+			return x.Done
+		}
+	}
+	return nil
+}
+
+type isPullRequest_Content interface {
+	isPullRequest_Content()
+}
+
+type PullRequest_Hello struct {
+	// first message on every connection
+	Hello *WorkerInfo `protobuf:"bytes,1,opt,name=hello,proto3,oneof"`
+}
+
+type PullRequest_Credit struct {
+	// number of additional tasks the worker accepts now; each PullTask
+	// consumes one, each PullDone is followed by a credit of 1.
+	// Tasks started on an earlier connection keep running: their pending
+	// output and PullDone are sent after hello, and the first credit on a
+	// connection excludes them
+	Credit int32 `protobuf:"varint,2,opt,name=credit,proto3,oneof"`
+}
+
+type PullRequest_Output struct {
+	Output *PullOutput `protobuf:"bytes,3,opt,name=output,proto3,oneof"`
+}
+
+type PullRequest_Done struct {
+	Done *PullDone `protobuf:"bytes,4,opt,name=done,proto3,oneof"`
+}
+
+func (*PullRequest_Hello) isPullRequest_Content() {}
+
+func (*PullRequest_Credit) isPullRequest_Content() {}
+
+func (*PullRequest_Output) isPullRequest_Content() {}
+
+func (*PullRequest_Done) isPullRequest_Content() {}
+
+type PullOutput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Response      *TaskResponse          `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullOutput) Reset() {
+	*x = PullOutput{}
+	mi := &file_proto_goumang_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullOutput) ProtoMessage() {}
+
+func (x *PullOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullOutput.ProtoReflect.Descriptor instead.
+func (*PullOutput) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *PullOutput) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *PullOutput) GetResponse() *TaskResponse {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+type PullDone struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// google.rpc.Code, 0 on success
+	Code    int32  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// process exit status, -1 when the failure carries none
+	ExitCode      int32 `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullDone) Reset() {
+	*x = PullDone{}
+	mi := &file_proto_goumang_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullDone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullDone) ProtoMessage() {}
+
+func (x *PullDone) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullDone.ProtoReflect.Descriptor instead.
+func (*PullDone) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PullDone) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *PullDone) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *PullDone) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PullDone) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+type PullResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Content:
+	//
+	//	*PullResponse_Task
+	//	*PullResponse_CancelTaskId
+	Content       isPullResponse_Content `protobuf_oneof:"content"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullResponse) Reset() {
+	*x = PullResponse{}
+	mi := &file_proto_goumang_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullResponse) ProtoMessage() {}
+
+func (x *PullResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullResponse.ProtoReflect.Descriptor instead.
+func (*PullResponse) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PullResponse) GetContent() isPullResponse_Content {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *PullResponse) GetTask() *PullTask {
+	if x != nil {
+		if x, ok := x.Content.(*PullResponse_Task); ok {
+			return x.Task
+		}
+	}
+	return nil
+}
+
+func (x *PullResponse) GetCancelTaskId() string {
+	if x != nil {
+		if x, ok := x.Content.(*PullResponse_CancelTaskId); ok {
+			return x.CancelTaskId
+		}
+	}
+	return ""
+}
+
+type isPullResponse_Content interface {
+	isPullResponse_Content()
+}
+
+type PullResponse_Task struct {
+	Task *PullTask `protobuf:"bytes,1,opt,name=task,proto3,oneof"`
+}
+
+type PullResponse_CancelTaskId struct {
+	// cancels a running task, the worker still reports PullDone
+	CancelTaskId string `protobuf:"bytes,2,opt,name=cancel_task_id,json=cancelTaskId,proto3,oneof"`
+}
+
+func (*PullResponse_Task) isPullResponse_Content() {}
+
+func (*PullResponse_CancelTaskId) isPullResponse_Content() {}
+
+type PullTask struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Request       *TaskRequest           `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullTask) Reset() {
+	*x = PullTask{}
+	mi := &file_proto_goumang_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullTask) ProtoMessage() {}
+
+func (x *PullTask) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_goumang_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullTask.ProtoReflect.Descriptor instead.
+func (*PullTask) Descriptor() ([]byte, []int) {
+	return file_proto_goumang_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PullTask) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *PullTask) GetRequest() *TaskRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+var File_proto_goumang_proto protoreflect.FileDescriptor
+
+const file_proto_goumang_proto_rawDesc = "" +
+	"\n" +
+	"\x13proto/goumang.proto\x12\agoumang\"\xb0\x02\n" +
+	"\vTaskRequest\x12'\n" +
+	"\x06method\x18\x01 \x01(\x0e2\x0f.goumang.MethodR\x06method\x12#\n" +
+	"\rmethod_params\x18\x02 \x01(\tR\fmethodParams\x12\x18\n" +
+	"\atimeout\x18\x03 \x01(\x05R\atimeout\x12\x1e\n" +
+	"\vrun_task_id\x18\x04 \x01(\x04R\trunTaskId\x12\x1c\n" +
+	"\tartifacts\x18\x05 \x03(\tR\tartifacts\x12\x1c\n" +
+	"\tsignature\x18\x06 \x01(\fR\tsignature\x12\x15\n" +
+	"\x06key_id\x18\a \x01(\tR\x05keyId\x12%\n" +
+	"\x0eparams_version\x18\b \x01(\rR\rparamsVersion\x12\x1f\n" +
+	"\vmethod_name\x18\t \x01(\tR\n" +
+	"methodName\"\xee\x01\n" +
+	"\fTaskResponse\x12\x18\n" +
+	"\x06output\x18\x01 \x01(\tH\x00R\x06output\x12\x16\n" +
+	"\x05error\x18\x02 \x01(\tH\x00R\x05error\x12-\n" +
+	"\x06result\x18\x03 \x01(\v2\x13.goumang.TaskResultH\x00R\x06result\x122\n" +
+	"\theartbeat\x18\x04 \x01(\v2\x12.goumang.HeartbeatH\x00R\theartbeat\x12*\n" +
+	"\x05event\x18\x05 \x01(\v2\x12.goumang.TaskEventH\x00R\x05event\x12\x12\n" +
+	"\x04node\x18\x06 \x01(\tR\x04nodeB\t\n" +
+	"\acontent\"\x98\x02\n" +
+	"\n" +
+	"TaskResult\x12\x1f\n" +
+	"\vtotal_bytes\x18\x01 \x01(\x04R\n" +
+	"totalBytes\x12'\n" +
+	"\x0fforwarded_bytes\x18\x02 \x01(\x04R\x0eforwardedBytes\x12\x1f\n" +
+	"\vtotal_lines\x18\x03 \x01(\x04R\n" +
+	"totalLines\x12'\n" +
+	"\x0fforwarded_lines\x18\x04 \x01(\x04R\x0eforwardedLines\x12\x1c\n" +
+	"\ttruncated\x18\x05 \x01(\bR\ttruncated\x12/\n" +
+	"\tartifacts\x18\x06 \x03(\v2\x11.goumang.ArtifactR\tartifacts\x12'\n" +
+	"\x0fworkspace_bytes\x18\a \x01(\x04R\x0eworkspaceBytes\"J\n" +
+	"\bArtifact\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x04R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\"b\n" +
+	"\x14FetchArtifactRequest\x12\x1e\n" +
+	"\vrun_task_id\x18\x01 \x01(\x04R\trunTaskId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\";\n" +
+	"\rArtifactChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\"`\n" +
+	"\x0ePutFileRequest\x12-\n" +
+	"\x06header\x18\x01 \x01(\v2\x13.goumang.FileHeaderH\x00R\x06header\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\t\n" +
+	"\acontent\"\xed\x01\n" +
+	"\n" +
+	"FileHeader\x12\x12\n" +
+	"\x04root\x18\x01 \x01(\tR\x04root\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\rR\x04mode\x12\x14\n" +
+	"\x05owner\x18\x04 \x01(\tR\x05owner\x12\x14\n" +
+	"\x05group\x18\x05 \x01(\tR\x05group\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x12\x12\n" +
+	"\x04size\x18\a \x01(\x03R\x04size\x12\x16\n" +
+	"\x06mkdirs\x18\b \x01(\bR\x06mkdirs\x12\x1c\n" +
+	"\tsignature\x18\t \x01(\fR\tsignature\x12\x15\n" +
+	"\x06key_id\x18\n" +
+	" \x01(\tR\x05keyId\"Q\n" +
+	"\x0fPutFileResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\"h\n" +
+	"\x0eGetFileRequest\x12\x12\n" +
+	"\x04root\x18\x01 \x01(\tR\x04root\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x04 \x01(\x03R\x06length\"~\n" +
+	"\tFileChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x10\n" +
+	"\x03eof\x18\x03 \x01(\bR\x03eof\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\x12\x1b\n" +
+	"\tfile_size\x18\x05 \x01(\x03R\bfileSize\"\xc4\x01\n" +
+	"\tHeartbeat\x12\x1d\n" +
+	"\n" +
+	"elapsed_ms\x18\x01 \x01(\x03R\telapsedMs\x12\x14\n" +
+	"\x05alive\x18\x02 \x01(\bR\x05alive\x12#\n" +
+	"\rprocess_count\x18\x03 \x01(\x05R\fprocessCount\x12\x1f\n" +
+	"\vcpu_seconds\x18\x04 \x01(\x01R\n" +
+	"cpuSeconds\x12\x1f\n" +
+	"\vcpu_percent\x18\x05 \x01(\x01R\n" +
+	"cpuPercent\x12\x1b\n" +
+	"\trss_bytes\x18\x06 \x01(\x04R\brssBytes\"\xff\x02\n" +
+	"\tTaskEvent\x12\x1c\n" +
+	"\bprogress\x18\x01 \x01(\x01H\x00R\bprogress\x12,\n" +
+	"\ametrics\x18\x02 \x01(\v2\x10.goumang.MetricsH\x00R\ametrics\x12\x18\n" +
+	"\x06status\x18\x03 \x01(\tH\x00R\x06status\x12<\n" +
+	"\rhttp_response\x18\x04 \x01(\v2\x15.goumang.HttpResponseH\x00R\fhttpResponse\x12,\n" +
+	"\acolumns\x18\x05 \x01(\v2\x10.goumang.ColumnsH\x00R\acolumns\x123\n" +
+	"\n" +
+	"step_start\x18\x06 \x01(\v2\x12.goumang.StepStartH\x00R\tstepStart\x12-\n" +
+	"\bstep_end\x18\a \x01(\v2\x10.goumang.StepEndH\x00R\astepEnd\x123\n" +
+	"\n" +
+	"node_state\x18\b \x01(\v2\x12.goumang.NodeStateH\x00R\tnodeStateB\a\n" +
+	"\x05event\"z\n" +
+	"\aMetrics\x124\n" +
+	"\x06values\x18\x01 \x03(\v2\x1c.goumang.Metrics.ValuesEntryR\x06values\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe4\x01\n" +
+	"\fHttpResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\x05R\n" +
+	"statusCode\x12<\n" +
+	"\aheaders\x18\x02 \x03(\v2\".goumang.HttpResponse.HeadersEntryR\aheaders\x12\x18\n" +
+	"\aattempt\x18\x03 \x01(\x05R\aattempt\x12\x1f\n" +
+	"\vbody_base64\x18\x04 \x01(\bR\n" +
+	"bodyBase64\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"5\n" +
+	"\aColumns\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\"\x7f\n" +
+	"\tStepStart\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12'\n" +
+	"\x06method\x18\x03 \x01(\x0e2\x0f.goumang.MethodR\x06method\x12\x1f\n" +
+	"\vmethod_name\x18\x04 \x01(\tR\n" +
+	"methodName\"\xb4\x01\n" +
+	"\aStepEnd\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\texit_code\x18\x03 \x01(\x05R\bexitCode\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12+\n" +
+	"\x06result\x18\x06 \x01(\v2\x13.goumang.TaskResultR\x06result\"\xcd\x01\n" +
+	"\tNodeState\x12\x12\n" +
+	"\x04node\x18\x01 \x01(\tR\x04node\x12+\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x13.goumang.NodeStatusR\x06status\x12\x1b\n" +
+	"\texit_code\x18\x03 \x01(\x05R\bexitCode\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\x12+\n" +
+	"\x06result\x18\x06 \x01(\v2\x13.goumang.TaskResultR\x06result\"\x18\n" +
+	"\x16DescribeMethodsRequest\"H\n" +
+	"\x17DescribeMethodsResponse\x12-\n" +
+	"\amethods\x18\x01 \x03(\v2\x13.goumang.MethodInfoR\amethods\"\xb7\x01\n" +
+	"\n" +
+	"MethodInfo\x12'\n" +
+	"\x06method\x18\x01 \x01(\x0e2\x0f.goumang.MethodR\x06method\x12%\n" +
+	"\x0eparams_version\x18\x02 \x01(\rR\rparamsVersion\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12#\n" +
+	"\rparams_schema\x18\x04 \x01(\tR\fparamsSchema\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\"\x16\n" +
+	"\x14GetWorkerInfoRequest\"\xa3\x03\n" +
+	"\n" +
+	"WorkerInfo\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12(\n" +
+	"\x05build\x18\x02 \x01(\v2\x12.goumang.BuildInfoR\x05build\x12\x1a\n" +
+	"\bhostname\x18\x03 \x01(\tR\bhostname\x127\n" +
+	"\x06labels\x18\x04 \x03(\v2\x1f.goumang.WorkerInfo.LabelsEntryR\x06labels\x122\n" +
+	"\amethods\x18\x05 \x03(\v2\x18.goumang.SupportedMethodR\amethods\x125\n" +
+	"\bsecurity\x18\x06 \x01(\v2\x19.goumang.SecuritySettingsR\bsecurity\x12-\n" +
+	"\x06limits\x18\a \x01(\v2\x15.goumang.WorkerLimitsR\x06limits\x12'\n" +
+	"\x04load\x18\b \x01(\v2\x13.goumang.WorkerLoadR\x04load\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x81\x01\n" +
+	"\tBuildInfo\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x01 \x01(\tR\tgoVersion\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\tR\brevision\x12\x1d\n" +
+	"\n" +
+	"build_time\x18\x03 \x01(\tR\tbuildTime\x12\x1a\n" +
+	"\bmodified\x18\x04 \x01(\bR\bmodified\"L\n" +
+	"\x0fSupportedMethod\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\x0eparams_version\x18\x02 \x01(\rR\rparamsVersion\"\xe1\x01\n" +
+	"\x10SecuritySettings\x12-\n" +
+	"\x12validation_enabled\x18\x01 \x01(\bR\x11validationEnabled\x12\x1f\n" +
+	"\vallow_pipes\x18\x02 \x01(\bR\n" +
+	"allowPipes\x12+\n" +
+	"\x11allow_redirection\x18\x03 \x01(\bR\x10allowRedirection\x12%\n" +
+	"\x0eallow_chaining\x18\x04 \x01(\bR\rallowChaining\x12)\n" +
+	"\x10signing_required\x18\x05 \x01(\bR\x0fsigningRequired\"\x96\x04\n" +
+	"\fWorkerLimits\x12.\n" +
+	"\x13default_timeout_sec\x18\x01 \x01(\x05R\x11defaultTimeoutSec\x12&\n" +
+	"\x0fmax_timeout_sec\x18\x02 \x01(\x05R\rmaxTimeoutSec\x12%\n" +
+	"\x0emax_concurrent\x18\x03 \x01(\x05R\rmaxConcurrent\x12b\n" +
+	"\x15method_max_concurrent\x18\x04 \x03(\v2..goumang.WorkerLimits.MethodMaxConcurrentEntryR\x13methodMaxConcurrent\x12;\n" +
+	"\x1amethod_default_timeout_sec\x18\x05 \x01(\x05R\x17methodDefaultTimeoutSec\x12Y\n" +
+	"\x12method_timeout_sec\x18\x06 \x03(\v2+.goumang.WorkerLimits.MethodTimeoutSecEntryR\x10methodTimeoutSec\x1aF\n" +
+	"\x18MethodMaxConcurrentEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1aC\n" +
+	"\x15MethodTimeoutSecEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xd7\x01\n" +
+	"\n" +
+	"WorkerLoad\x12#\n" +
+	"\rrunning_tasks\x18\x01 \x01(\x05R\frunningTasks\x12\x17\n" +
+	"\anum_cpu\x18\x02 \x01(\x05R\x06numCpu\x12:\n" +
+	"\amethods\x18\x03 \x03(\v2 .goumang.WorkerLoad.MethodsEntryR\amethods\x1aO\n" +
+	"\fMethodsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12)\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.goumang.MethodLoadR\x05value:\x028\x01\"\x80\x01\n" +
+	"\n" +
+	"MethodLoad\x12\x18\n" +
+	"\arunning\x18\x01 \x01(\x03R\arunning\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12*\n" +
+	"\x11total_duration_ms\x18\x04 \x01(\x03R\x0ftotalDurationMs\"T\n" +
+	"\x0fRegisterRequest\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12'\n" +
+	"\x04info\x18\x02 \x01(\v2\x13.goumang.WorkerInfoR\x04info\"e\n" +
+	"\x10RegisterResponse\x12\x1b\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\x124\n" +
+	"\x16heartbeat_interval_sec\x18\x02 \x01(\x05R\x14heartbeatIntervalSec\"X\n" +
+	"\x10HeartbeatRequest\x12\x1b\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\x12'\n" +
+	"\x04load\x18\x02 \x01(\v2\x13.goumang.WorkerLoadR\x04load\"\x13\n" +
+	"\x11HeartbeatResponse\"0\n" +
+	"\x11DeregisterRequest\x12\x1b\n" +
+	"\tworker_id\x18\x01 \x01(\tR\bworkerId\"\x14\n" +
+	"\x12DeregisterResponse\"\xb7\x01\n" +
+	"\vPullRequest\x12+\n" +
+	"\x05hello\x18\x01 \x01(\v2\x13.goumang.WorkerInfoH\x00R\x05hello\x12\x18\n" +
+	"\x06credit\x18\x02 \x01(\x05H\x00R\x06credit\x12-\n" +
+	"\x06output\x18\x03 \x01(\v2\x13.goumang.PullOutputH\x00R\x06output\x12'\n" +
+	"\x04done\x18\x04 \x01(\v2\x11.goumang.PullDoneH\x00R\x04doneB\t\n" +
+	"\acontent\"X\n" +
+	"\n" +
+	"PullOutput\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x121\n" +
+	"\bresponse\x18\x02 \x01(\v2\x15.goumang.TaskResponseR\bresponse\"n\n" +
+	"\bPullDone\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1b\n" +
+	"\texit_code\x18\x04 \x01(\x05R\bexitCode\"j\n" +
+	"\fPullResponse\x12'\n" +
+	"\x04task\x18\x01 \x01(\v2\x11.goumang.PullTaskH\x00R\x04task\x12&\n" +
+	"\x0ecancel_task_id\x18\x02 \x01(\tH\x00R\fcancelTaskIdB\t\n" +
+	"\acontent\"S\n" +
+	"\bPullTask\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12.\n" +
+	"\arequest\x18\x02 \x01(\v2\x14.goumang.TaskRequestR\arequest*g\n" +
+	"\n" +
+	"NodeStatus\x12\x10\n" +
+	"\fNODE_PENDING\x10\x00\x12\x10\n" +
+	"\fNODE_RUNNING\x10\x01\x12\x12\n" +
+	"\x0eNODE_SUCCEEDED\x10\x02\x12\x0f\n" +
+	"\vNODE_FAILED\x10\x03\x12\x10\n" +
+	"\fNODE_SKIPPED\x10\x04*\x89\x01\n" +
+	"\x06Method\x12\t\n" +
+	"\x05SHELL\x10\x00\x12\b\n" +
+	"\x04HTTP\x10\x01\x12\r\n" +
+	"\tSH_INTERP\x10\x02\x12\n" +
+	"\n" +
+	"\x06SCRIPT\x10\x03\x12\a\n" +
+	"\x03SQL\x10\x04\x12\r\n" +
+	"\tGRPC_CALL\x10\x05\x12\b\n" +
+	"\x04WASM\x10\x06\x12\v\n" +
+	"\aARCHIVE\x10\a\x12\f\n" +
+	"\bPIPELINE\x10\b\x12\a\n" +
+	"\x03DAG\x10\t\x12\t\n" +
+	"\x05OTHER\x10d2\x9b\x03\n" +
+	"\x04Task\x124\n" +
+	"\x03Run\x12\x14.goumang.TaskRequest\x1a\x15.goumang.TaskResponse0\x01\x12H\n" +
+	"\rFetchArtifact\x12\x1d.goumang.FetchArtifactRequest\x1a\x16.goumang.ArtifactChunk0\x01\x12>\n" +
+	"\aPutFile\x12\x17.goumang.PutFileRequest\x1a\x18.goumang.PutFileResponse(\x01\x128\n" +
+	"\aGetFile\x12\x17.goumang.GetFileRequest\x1a\x12.goumang.FileChunk0\x01\x12T\n" +
+	"\x0fDescribeMethods\x12\x1f.goumang.DescribeMethodsRequest\x1a .goumang.DescribeMethodsResponse\x12C\n" +
+	"\rGetWorkerInfo\x12\x1d.goumang.GetWorkerInfoRequest\x1a\x13.goumang.WorkerInfo2\x90\x02\n" +
+	"\tScheduler\x12?\n" +
+	"\bRegister\x12\x18.goumang.RegisterRequest\x1a\x19.goumang.RegisterResponse\x12B\n" +
+	"\tHeartbeat\x12\x19.goumang.HeartbeatRequest\x1a\x1a.goumang.HeartbeatResponse\x12E\n" +
+	"\n" +
+	"Deregister\x12\x1a.goumang.DeregisterRequest\x1a\x1b.goumang.DeregisterResponse\x127\n" +
+	"\x04Pull\x12\x14.goumang.PullRequest\x1a\x15.goumang.PullResponse(\x010\x01B\x1cZ\x1agoumang-worker/services/pbb\x06proto3"
+
+var (
+	file_proto_goumang_proto_rawDescOnce sync.Once
+	file_proto_goumang_proto_rawDescData []byte
+)
+
+func file_proto_goumang_proto_rawDescGZIP() []byte {
+	file_proto_goumang_proto_rawDescOnce.Do(func() {
+		file_proto_goumang_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_goumang_proto_rawDesc), len(file_proto_goumang_proto_rawDesc)))
+	})
+	return file_proto_goumang_proto_rawDescData
+}
+
+var file_proto_goumang_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_goumang_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
+var file_proto_goumang_proto_goTypes = []any{
+	(NodeStatus)(0),                 // 0: goumang.NodeStatus
+	(Method)(0),                     // 1: goumang.Method
+	(*TaskRequest)(nil),             // 2: goumang.TaskRequest
+	(*TaskResponse)(nil),            // 3: goumang.TaskResponse
+	(*TaskResult)(nil),              // 4: goumang.TaskResult
+	(*Artifact)(nil),                // 5: goumang.Artifact
+	(*FetchArtifactRequest)(nil),    // 6: goumang.FetchArtifactRequest
+	(*ArtifactChunk)(nil),           // 7: goumang.ArtifactChunk
+	(*PutFileRequest)(nil),          // 8: goumang.PutFileRequest
+	(*FileHeader)(nil),              // 9: goumang.FileHeader
+	(*PutFileResponse)(nil),         // 10: goumang.PutFileResponse
+	(*GetFileRequest)(nil),          // 11: goumang.GetFileRequest
+	(*FileChunk)(nil),               // 12: goumang.FileChunk
+	(*Heartbeat)(nil),               // 13: goumang.Heartbeat
+	(*TaskEvent)(nil),               // 14: goumang.TaskEvent
+	(*Metrics)(nil),                 // 15: goumang.Metrics
+	(*HttpResponse)(nil),            // 16: goumang.HttpResponse
+	(*Columns)(nil),                 // 17: goumang.Columns
+	(*StepStart)(nil),               // 18: goumang.StepStart
+	(*StepEnd)(nil),                 // 19: goumang.StepEnd
+	(*NodeState)(nil),               // 20: goumang.NodeState
+	(*DescribeMethodsRequest)(nil),  // 21: goumang.DescribeMethodsRequest
+	(*DescribeMethodsResponse)(nil), // 22: goumang.DescribeMethodsResponse
+	(*MethodInfo)(nil),              // 23: goumang.MethodInfo
+	(*GetWorkerInfoRequest)(nil),    // 24: goumang.GetWorkerInfoRequest
+	(*WorkerInfo)(nil),              // 25: goumang.WorkerInfo
+	(*BuildInfo)(nil),               // 26: goumang.BuildInfo
+	(*SupportedMethod)(nil),         // 27: goumang.SupportedMethod
+	(*SecuritySettings)(nil),        // 28: goumang.SecuritySettings
+	(*WorkerLimits)(nil),            // 29: goumang.WorkerLimits
+	(*WorkerLoad)(nil),              // 30: goumang.WorkerLoad
+	(*MethodLoad)(nil),              // 31: goumang.MethodLoad
+	(*RegisterRequest)(nil),         // 32: goumang.RegisterRequest
+	(*RegisterResponse)(nil),        // 33: goumang.RegisterResponse
+	(*HeartbeatRequest)(nil),        // 34: goumang.HeartbeatRequest
+	(*HeartbeatResponse)(nil),       // 35: goumang.HeartbeatResponse
+	(*DeregisterRequest)(nil),       // 36: goumang.DeregisterRequest
+	(*DeregisterResponse)(nil),      // 37: goumang.DeregisterResponse
+	(*PullRequest)(nil),             // 38: goumang.PullRequest
+	(*PullOutput)(nil),              // 39: goumang.PullOutput
+	(*PullDone)(nil),                // 40: goumang.PullDone
+	(*PullResponse)(nil),            // 41: goumang.PullResponse
+	(*PullTask)(nil),                // 42: goumang.PullTask
+	nil,                             // 43: goumang.Metrics.ValuesEntry
+	nil,                             // 44: goumang.HttpResponse.HeadersEntry
+	nil,                             // 45: goumang.WorkerInfo.LabelsEntry
+	nil,                             // 46: goumang.WorkerLimits.MethodMaxConcurrentEntry
+	nil,                             // 47: goumang.WorkerLimits.MethodTimeoutSecEntry
+	nil,                             // 48: goumang.WorkerLoad.MethodsEntry
+}
+var file_proto_goumang_proto_depIdxs = []int32{
+	1,  // 0: goumang.TaskRequest.method:type_name -> goumang.Method
+	4,  // 1: goumang.TaskResponse.result:type_name -> goumang.TaskResult
+	13, // 2: goumang.TaskResponse.heartbeat:type_name -> goumang.Heartbeat
+	14, // 3: goumang.TaskResponse.event:type_name -> goumang.TaskEvent
+	5,  // 4: goumang.TaskResult.artifacts:type_name -> goumang.Artifact
+	9,  // 5: goumang.PutFileRequest.header:type_name -> goumang.FileHeader
+	15, // 6: goumang.TaskEvent.metrics:type_name -> goumang.Metrics
+	16, // 7: goumang.TaskEvent.http_response:type_name -> goumang.HttpResponse
+	17, // 8: goumang.TaskEvent.columns:type_name -> goumang.Columns
+	18, // 9: goumang.TaskEvent.step_start:type_name -> goumang.StepStart
+	19, // 10: goumang.TaskEvent.step_end:type_name -> goumang.StepEnd
+	20, // 11: goumang.TaskEvent.node_state:type_name -> goumang.NodeState
+	43, // 12: goumang.Metrics.values:type_name -> goumang.Metrics.ValuesEntry
+	44, // 13: goumang.HttpResponse.headers:type_name -> goumang.HttpResponse.HeadersEntry
+	1,  // 14: goumang.StepStart.method:type_name -> goumang.Method
+	4,  // 15: goumang.StepEnd.result:type_name -> goumang.TaskResult
+	0,  // 16: goumang.NodeState.status:type_name -> goumang.NodeStatus
+	4,  // 17: goumang.NodeState.result:type_name -> goumang.TaskResult
+	23, // 18: goumang.DescribeMethodsResponse.methods:type_name -> goumang.MethodInfo
+	1,  // 19: goumang.MethodInfo.method:type_name -> goumang.Method
+	26, // 20: goumang.WorkerInfo.build:type_name -> goumang.BuildInfo
+	45, // 21: goumang.WorkerInfo.labels:type_name -> goumang.WorkerInfo.LabelsEntry
+	27, // 22: goumang.WorkerInfo.methods:type_name -> goumang.SupportedMethod
+	28, // 23: goumang.WorkerInfo.security:type_name -> goumang.SecuritySettings
+	29, // 24: goumang.WorkerInfo.limits:type_name -> goumang.WorkerLimits
+	30, // 25: goumang.WorkerInfo.load:type_name -> goumang.WorkerLoad
+	46, // 26: goumang.WorkerLimits.method_max_concurrent:type_name -> goumang.WorkerLimits.MethodMaxConcurrentEntry
+	47, // 27: goumang.WorkerLimits.method_timeout_sec:type_name -> goumang.WorkerLimits.MethodTimeoutSecEntry
+	48, // 28: goumang.WorkerLoad.methods:type_name -> goumang.WorkerLoad.MethodsEntry
+	25, // 29: goumang.RegisterRequest.info:type_name -> goumang.WorkerInfo
+	30, // 30: goumang.HeartbeatRequest.load:type_name -> goumang.WorkerLoad
+	25, // 31: goumang.PullRequest.hello:type_name -> goumang.WorkerInfo
+	39, // 32: goumang.PullRequest.output:type_name -> goumang.PullOutput
+	40, // 33: goumang.PullRequest.done:type_name -> goumang.PullDone
+	3,  // 34: goumang.PullOutput.response:type_name -> goumang.TaskResponse
+	42, // 35: goumang.PullResponse.task:type_name -> goumang.PullTask
+	2,  // 36: goumang.PullTask.request:type_name -> goumang.TaskRequest
+	31, // 37: goumang.WorkerLoad.MethodsEntry.value:type_name -> goumang.MethodLoad
+	2,  // 38: goumang.Task.Run:input_type -> goumang.TaskRequest
+	6,  // 39: goumang.Task.FetchArtifact:input_type -> goumang.FetchArtifactRequest
+	8,  // 40: goumang.Task.PutFile:input_type -> goumang.PutFileRequest
+	11, // 41: goumang.Task.GetFile:input_type -> goumang.GetFileRequest
+	21, // 42: goumang.Task.DescribeMethods:input_type -> goumang.DescribeMethodsRequest
+	24, // 43: goumang.Task.GetWorkerInfo:input_type -> goumang.GetWorkerInfoRequest
+	32, // 44: goumang.Scheduler.Register:input_type -> goumang.RegisterRequest
+	34, // 45: goumang.Scheduler.Heartbeat:input_type -> goumang.HeartbeatRequest
+	36, // 46: goumang.Scheduler.Deregister:input_type -> goumang.DeregisterRequest
+	38, // 47: goumang.Scheduler.Pull:input_type -> goumang.PullRequest
+	3,  // 48: goumang.Task.Run:output_type -> goumang.TaskResponse
+	7,  // 49: goumang.Task.FetchArtifact:output_type -> goumang.ArtifactChunk
+	10, // 50: goumang.Task.PutFile:output_type -> goumang.PutFileResponse
+	12, // 51: goumang.Task.GetFile:output_type -> goumang.FileChunk
+	22, // 52: goumang.Task.DescribeMethods:output_type -> goumang.DescribeMethodsResponse
+	25, // 53: goumang.Task.GetWorkerInfo:output_type -> goumang.WorkerInfo
+	33, // 54: goumang.Scheduler.Register:output_type -> goumang.RegisterResponse
+	35, // 55: goumang.Scheduler.Heartbeat:output_type -> goumang.HeartbeatResponse
+	37, // 56: goumang.Scheduler.Deregister:output_type -> goumang.DeregisterResponse
+	41, // 57: goumang.Scheduler.Pull:output_type -> goumang.PullResponse
+	48, // [48:58] is the sub-list for method output_type
+	38, // [38:48] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
+}
+
+func init() { file_proto_goumang_proto_init() }
+func file_proto_goumang_proto_init() {
+	if File_proto_goumang_proto != nil {
+		return
+	}
+	file_proto_goumang_proto_msgTypes[1].OneofWrappers = []any{
+		(*TaskResponse_Output)(nil),
+		(*TaskResponse_Error)(nil),
+		(*TaskResponse_Result)(nil),
+		(*TaskResponse_Heartbeat)(nil),
+		(*TaskResponse_Event)(nil),
+	}
+	file_proto_goumang_proto_msgTypes[6].OneofWrappers = []any{
+		(*PutFileRequest_Header)(nil),
+		(*PutFileRequest_Data)(nil),
+	}
+	file_proto_goumang_proto_msgTypes[12].OneofWrappers = []any{
+		(*TaskEvent_Progress)(nil),
+		(*TaskEvent_Metrics)(nil),
+		(*TaskEvent_Status)(nil),
+		(*TaskEvent_HttpResponse)(nil),
+		(*TaskEvent_Columns)(nil),
+		(*TaskEvent_StepStart)(nil),
+		(*TaskEvent_StepEnd)(nil),
+		(*TaskEvent_NodeState)(nil),
+	}
+	file_proto_goumang_proto_msgTypes[36].OneofWrappers = []any{
+		(*PullRequest_Hello)(nil),
+		(*PullRequest_Credit)(nil),
+		(*PullRequest_Output)(nil),
+		(*PullRequest_Done)(nil),
+	}
+	file_proto_goumang_proto_msgTypes[39].OneofWrappers = []any{
+		(*PullResponse_Task)(nil),
+		(*PullResponse_CancelTaskId)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_goumang_proto_rawDesc), len(file_proto_goumang_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      2,
+			NumMessages:   47,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_proto_goumang_proto_goTypes,
 		DependencyIndexes: file_proto_goumang_proto_depIdxs,
@@ -19,7 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Task_Run_FullMethodName = "/goumang.Task/Run"
+	Task_Run_FullMethodName             = "/goumang.Task/Run"
+	Task_FetchArtifact_FullMethodName   = "/goumang.Task/FetchArtifact"
+	Task_PutFile_FullMethodName         = "/goumang.Task/PutFile"
+	Task_GetFile_FullMethodName         = "/goumang.Task/GetFile"
+	Task_DescribeMethods_FullMethodName = "/goumang.Task/DescribeMethods"
+	Task_GetWorkerInfo_FullMethodName   = "/goumang.Task/GetWorkerInfo"
 )
 
 // TaskClient is the client API for Task service.
@@ -27,6 +32,11 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TaskClient interface {
 	Run(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TaskResponse], error)
+	FetchArtifact(ctx context.Context, in *FetchArtifactRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArtifactChunk], error)
+	PutFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[PutFileRequest, PutFileResponse], error)
+	GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error)
+	DescribeMethods(ctx context.Context, in *DescribeMethodsRequest, opts ...grpc.CallOption) (*DescribeMethodsResponse, error)
+	GetWorkerInfo(ctx context.Context, in *GetWorkerInfoRequest, opts ...grpc.CallOption) (*WorkerInfo, error)
 }
 
 type taskClient struct {
@@ -56,11 +66,87 @@ func (c *taskClient) Run(ctx context.Context, in *TaskRequest, opts ...grpc.Call
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Task_RunClient = grpc.ServerStreamingClient[TaskResponse]
 
+func (c *taskClient) FetchArtifact(ctx context.Context, in *FetchArtifactRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ArtifactChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Task_ServiceDesc.Streams[1], Task_FetchArtifact_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FetchArtifactRequest, ArtifactChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Task_FetchArtifactClient = grpc.ServerStreamingClient[ArtifactChunk]
+
+func (c *taskClient) PutFile(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[PutFileRequest, PutFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Task_ServiceDesc.Streams[2], Task_PutFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PutFileRequest, PutFileResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Task_PutFileClient = grpc.ClientStreamingClient[PutFileRequest, PutFileResponse]
+
+func (c *taskClient) GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FileChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Task_ServiceDesc.Streams[3], Task_GetFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetFileRequest, FileChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Task_GetFileClient = grpc.ServerStreamingClient[FileChunk]
+
+func (c *taskClient) DescribeMethods(ctx context.Context, in *DescribeMethodsRequest, opts ...grpc.CallOption) (*DescribeMethodsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DescribeMethodsResponse)
+	err := c.cc.Invoke(ctx, Task_DescribeMethods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskClient) GetWorkerInfo(ctx context.Context, in *GetWorkerInfoRequest, opts ...grpc.CallOption) (*WorkerInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkerInfo)
+	err := c.cc.Invoke(ctx, Task_GetWorkerInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TaskServer is the server API for Task service.
 // All implementations must embed UnimplementedTaskServer
 // for forward compatibility.
 type TaskServer interface {
 	Run(*TaskRequest, grpc.ServerStreamingServer[TaskResponse]) error
+	FetchArtifact(*FetchArtifactRequest, grpc.ServerStreamingServer[ArtifactChunk]) error
+	PutFile(grpc.ClientStreamingServer[PutFileRequest, PutFileResponse]) error
+	GetFile(*GetFileRequest, grpc.ServerStreamingServer[FileChunk]) error
+	DescribeMethods(context.Context, *DescribeMethodsRequest) (*DescribeMethodsResponse, error)
+	GetWorkerInfo(context.Context, *GetWorkerInfoRequest) (*WorkerInfo, error)
 	mustEmbedUnimplementedTaskServer()
 }
 
@@ -74,6 +160,21 @@ type UnimplementedTaskServer struct{}
 func (UnimplementedTaskServer) Run(*TaskRequest, grpc.ServerStreamingServer[TaskResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method Run not implemented")
 }
+func (UnimplementedTaskServer) FetchArtifact(*FetchArtifactRequest, grpc.ServerStreamingServer[ArtifactChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method FetchArtifact not implemented")
+}
+func (UnimplementedTaskServer) PutFile(grpc.ClientStreamingServer[PutFileRequest, PutFileResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method PutFile not implemented")
+}
+func (UnimplementedTaskServer) GetFile(*GetFileRequest, grpc.ServerStreamingServer[FileChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method GetFile not implemented")
+}
+func (UnimplementedTaskServer) DescribeMethods(context.Context, *DescribeMethodsRequest) (*DescribeMethodsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeMethods not implemented")
+}
+func (UnimplementedTaskServer) GetWorkerInfo(context.Context, *GetWorkerInfoRequest) (*WorkerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkerInfo not implemented")
+}
 func (UnimplementedTaskServer) mustEmbedUnimplementedTaskServer() {}
 func (UnimplementedTaskServer) testEmbeddedByValue()              {}
 
@@ -106,19 +207,327 @@ func _Task_Run_Handler(srv interface{}, stream grpc.ServerStream) error {
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Task_RunServer = grpc.ServerStreamingServer[TaskResponse]
 
+func _Task_FetchArtifact_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchArtifactRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskServer).FetchArtifact(m, &grpc.GenericServerStream[FetchArtifactRequest, ArtifactChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Task_FetchArtifactServer = grpc.ServerStreamingServer[ArtifactChunk]
+
+func _Task_PutFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TaskServer).PutFile(&grpc.GenericServerStream[PutFileRequest, PutFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Task_PutFileServer = grpc.ClientStreamingServer[PutFileRequest, PutFileResponse]
+
+func _Task_GetFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskServer).GetFile(m, &grpc.GenericServerStream[GetFileRequest, FileChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Task_GetFileServer = grpc.ServerStreamingServer[FileChunk]
+
+func _Task_DescribeMethods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeMethodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServer).DescribeMethods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Task_DescribeMethods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServer).DescribeMethods(ctx, req.(*DescribeMethodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Task_GetWorkerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorkerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServer).GetWorkerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Task_GetWorkerInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServer).GetWorkerInfo(ctx, req.(*GetWorkerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Task_ServiceDesc is the grpc.ServiceDesc for Task service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var Task_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "goumang.Task",
 	HandlerType: (*TaskServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DescribeMethods",
+			Handler:    _Task_DescribeMethods_Handler,
+		},
+		{
+			MethodName: "GetWorkerInfo",
+			Handler:    _Task_GetWorkerInfo_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Run",
 			Handler:       _Task_Run_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "FetchArtifact",
+			Handler:       _Task_FetchArtifact_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PutFile",
+			Handler:       _Task_PutFile_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetFile",
+			Handler:       _Task_GetFile_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/goumang.proto",
+}
+
+const (
+	Scheduler_Register_FullMethodName   = "/goumang.Scheduler/Register"
+	Scheduler_Heartbeat_FullMethodName  = "/goumang.Scheduler/Heartbeat"
+	Scheduler_Deregister_FullMethodName = "/goumang.Scheduler/Deregister"
+	Scheduler_Pull_FullMethodName       = "/goumang.Scheduler/Pull"
+)
+
+// SchedulerClient is the client API for Scheduler service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// implemented by the scheduler, called by workers with registration enabled
+type SchedulerClient interface {
+	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
+	// NotFound for an unknown worker_id makes the worker register again
+	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	Deregister(ctx context.Context, in *DeregisterRequest, opts ...grpc.CallOption) (*DeregisterResponse, error)
+	// pull mode: the worker dials out and receives tasks on the same stream
+	Pull(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PullRequest, PullResponse], error)
+}
+
+type schedulerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSchedulerClient(cc grpc.ClientConnInterface) SchedulerClient {
+	return &schedulerClient{cc}
+}
+
+func (c *schedulerClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, Scheduler_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HeartbeatResponse)
+	err := c.cc.Invoke(ctx, Scheduler_Heartbeat_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) Deregister(ctx context.Context, in *DeregisterRequest, opts ...grpc.CallOption) (*DeregisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeregisterResponse)
+	err := c.cc.Invoke(ctx, Scheduler_Deregister_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerClient) Pull(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PullRequest, PullResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Scheduler_ServiceDesc.Streams[0], Scheduler_Pull_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PullRequest, PullResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Scheduler_PullClient = grpc.BidiStreamingClient[PullRequest, PullResponse]
+
+// SchedulerServer is the server API for Scheduler service.
+// All implementations must embed UnimplementedSchedulerServer
+// for forward compatibility.
+//
+// implemented by the scheduler, called by workers with registration enabled
+type SchedulerServer interface {
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	// NotFound for an unknown worker_id makes the worker register again
+	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	Deregister(context.Context, *DeregisterRequest) (*DeregisterResponse, error)
+	// pull mode: the worker dials out and receives tasks on the same stream
+	Pull(grpc.BidiStreamingServer[PullRequest, PullResponse]) error
+	mustEmbedUnimplementedSchedulerServer()
+}
+
+// UnimplementedSchedulerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSchedulerServer struct{}
+
+func (UnimplementedSchedulerServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedSchedulerServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedSchedulerServer) Deregister(context.Context, *DeregisterRequest) (*DeregisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deregister not implemented")
+}
+func (UnimplementedSchedulerServer) Pull(grpc.BidiStreamingServer[PullRequest, PullResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Pull not implemented")
+}
+func (UnimplementedSchedulerServer) mustEmbedUnimplementedSchedulerServer() {}
+func (UnimplementedSchedulerServer) testEmbeddedByValue()                   {}
+
+// UnsafeSchedulerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SchedulerServer will
+// result in compilation errors.
+type UnsafeSchedulerServer interface {
+	mustEmbedUnimplementedSchedulerServer()
+}
+
+func RegisterSchedulerServer(s grpc.ServiceRegistrar, srv SchedulerServer) {
+	// If the following call pancis, it indicates UnimplementedSchedulerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Scheduler_ServiceDesc, srv)
+}
+
+func _Scheduler_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).Heartbeat(ctx, req.(*HeartbeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_Deregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServer).Deregister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Scheduler_Deregister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServer).Deregister(ctx, req.(*DeregisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Scheduler_Pull_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SchedulerServer).Pull(&grpc.GenericServerStream[PullRequest, PullResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Scheduler_PullServer = grpc.BidiStreamingServer[PullRequest, PullResponse]
+
+// Scheduler_ServiceDesc is the grpc.ServiceDesc for Scheduler service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Scheduler_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "goumang.Scheduler",
+	HandlerType: (*SchedulerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _Scheduler_Register_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _Scheduler_Heartbeat_Handler,
+		},
+		{
+			MethodName: "Deregister",
+			Handler:    _Scheduler_Deregister_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Pull",
+			Handler:       _Scheduler_Pull_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "proto/goumang.proto",
 }
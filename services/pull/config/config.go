@@ -0,0 +1,69 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// PullConfig 拉取模式配置
+type PullConfig struct {
+	// 是否启用拉取模式，启用后不再监听入站端口
+	Enabled bool `yaml:"enabled"`
+	// 调度端地址
+	Endpoint string `yaml:"endpoint"`
+	// 是否使用 TLS
+	TLS bool `yaml:"tls"`
+	// 同时执行的最大任务数
+	MaxConcurrent int `yaml:"maxConcurrent"`
+	// 断线后重连的初始等待秒数，连续失败时翻倍
+	ReconnectBackoffSec int `yaml:"reconnectBackoffSec"`
+	// 重连等待秒数上限
+	MaxReconnectBackoffSec int `yaml:"maxReconnectBackoffSec"`
+	// 断线期间最多暂存的输出消息数，超出后丢弃并在重连后提示
+	MaxPendingOutputs int `yaml:"maxPendingOutputs"`
+}
+
+// Config 拉取模式配置结构
+type Config struct {
+	Pull PullConfig `yaml:"pull"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "pull.yaml"), &globalConfig); err != nil {
+			panic("loadConfig pull.yaml err:" + err.Error())
+		}
+
+		// 设置默认值
+		if globalConfig.Pull.MaxConcurrent <= 0 {
+			globalConfig.Pull.MaxConcurrent = 4
+		}
+		if globalConfig.Pull.ReconnectBackoffSec <= 0 {
+			globalConfig.Pull.ReconnectBackoffSec = 1
+		}
+		if globalConfig.Pull.MaxReconnectBackoffSec <= 0 {
+			globalConfig.Pull.MaxReconnectBackoffSec = 60
+		}
+		if globalConfig.Pull.MaxPendingOutputs <= 0 {
+			globalConfig.Pull.MaxPendingOutputs = 10000
+		}
+		if globalConfig.Pull.MaxReconnectBackoffSec < globalConfig.Pull.ReconnectBackoffSec {
+			globalConfig.Pull.MaxReconnectBackoffSec = globalConfig.Pull.ReconnectBackoffSec
+		}
+	})
+}
+
+// GetPullConfig 获取拉取模式配置
+func GetPullConfig() PullConfig {
+	lazyLoadConfig()
+	return globalConfig.Pull
+}
@@ -0,0 +1,310 @@
+package pull
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/pb"
+	"goumang-worker/services/pull/config"
+	"sync"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// stableConnDuration 连接保持超过该时长后断开，重连等待时间重置
+const stableConnDuration = time.Minute
+
+// TaskServer 执行任务并提供 worker 信息，goumang.Server 即满足
+type TaskServer interface {
+	Execute(ctx context.Context, req *pb.TaskRequest, sink executor.Sink) error
+	GetWorkerInfo(ctx context.Context, req *pb.GetWorkerInfoRequest) (*pb.WorkerInfo, error)
+}
+
+// IsEnabled 是否以拉取模式运行
+func IsEnabled() bool {
+	return config.GetPullConfig().Enabled
+}
+
+// Run 连接调度端拉取任务，断线后按退避时间重连，ctx 结束时等待任务结束后返回
+// 任务按 worker 生命周期执行，不随连接断开而取消；断线期间的输出暂存，重连后在新连接上继续回传
+func Run(ctx context.Context, server TaskServer) error {
+	cfg := config.GetPullConfig()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	creds := insecure.NewCredentials()
+	if cfg.TLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.NewClient(cfg.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errC := conn.Close(); errC != nil {
+			logit.Context(ctx).WarnW("conn.Close.Err", errC)
+		}
+	}()
+
+	r := &runner{
+		ctx:     ctx,
+		server:  server,
+		cfg:     cfg,
+		tasks:   make(map[string]context.CancelFunc),
+		dropped: make(map[string]int),
+	}
+	// 任务使用 ctx，ctx 结束时随之取消，等待其结束后再关闭连接
+	defer r.wg.Wait()
+
+	client := pb.NewSchedulerClient(conn)
+	backoff := time.Duration(cfg.ReconnectBackoffSec) * time.Second
+	maxBackoff := time.Duration(cfg.MaxReconnectBackoffSec) * time.Second
+	wait := backoff
+
+	for ctx.Err() == nil {
+		start := time.Now()
+		err = r.runSession(client)
+		if ctx.Err() != nil {
+			break
+		}
+		logit.Context(ctx).WarnW("pull.session.Err", err, "endpoint", cfg.Endpoint)
+
+		if time.Since(start) > stableConnDuration {
+			wait = backoff
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+		wait = min(wait*2, maxBackoff)
+	}
+	return nil
+}
+
+// runner 拉取模式的任务执行器，跨连接保存正在执行的任务与断线期间的待发送消息
+type runner struct {
+	// worker 生命周期的上下文，任务在其上执行
+	ctx    context.Context
+	server TaskServer
+	cfg    config.PullConfig
+	// 任务 ID 到取消函数
+	tasks   map[string]context.CancelFunc
+	tasksMu sync.Mutex
+	// sendMu 保护以下字段，并串行化发送（stream.Send 不支持并发调用）；与 tasksMu 同时持有时先取 sendMu
+	sendMu sync.Mutex
+	// 当前连接，断线期间为 nil
+	stream pb.Scheduler_PullClient
+	// 断线期间暂存的输出与结果，重连后按顺序发送
+	pending []*pb.PullRequest
+	// 暂存已满时各任务丢弃的输出条数
+	dropped map[string]int
+	wg      sync.WaitGroup
+}
+
+// runSession 建立连接并上报 worker 信息，补发断线期间的消息与名额，然后持续接收任务直到连接断开
+func (r *runner) runSession(client pb.SchedulerClient) error {
+	ctx, cancel := context.WithCancel(r.ctx)
+	defer cancel()
+
+	stream, err := client.Pull(ctx)
+	if err != nil {
+		return err
+	}
+	// 先取消连接，使阻塞中的发送返回，再解除绑定
+	defer func() {
+		cancel()
+		r.detach(stream)
+	}()
+
+	info, err := r.server.GetWorkerInfo(ctx, &pb.GetWorkerInfoRequest{})
+	if err != nil {
+		return err
+	}
+	if err = stream.Send(&pb.PullRequest{Content: &pb.PullRequest_Hello{Hello: info}}); err != nil {
+		return err
+	}
+	running, err := r.attach(stream)
+	if err != nil {
+		return err
+	}
+	logit.Context(ctx).InfoW("logType", "pull connected", "endpoint", r.cfg.Endpoint,
+		"maxConcurrent", r.cfg.MaxConcurrent, "running", running)
+
+	for {
+		resp, errR := stream.Recv()
+		if errR != nil {
+			return errR
+		}
+		switch content := resp.Content.(type) {
+		case *pb.PullResponse_Task:
+			r.start(content.Task)
+		case *pb.PullResponse_CancelTaskId:
+			r.cancel(content.CancelTaskId)
+		}
+	}
+}
+
+// attach 在新连接上补发暂存的消息，再按空闲名额发放 credit，返回仍在执行的任务数
+// 上一条连接上开始的任务继续在新连接上回传输出与 PullDone
+func (r *runner) attach(stream pb.Scheduler_PullClient) (int, error) {
+	r.sendMu.Lock()
+	defer r.sendMu.Unlock()
+
+	for taskID, n := range r.dropped {
+		notice := fmt.Sprintf("%d output messages dropped while disconnected from scheduler", n)
+		r.pending = append(r.pending, outputRequest(taskID, &pb.TaskResponse{Content: &pb.TaskResponse_Error{Error: notice}}))
+		delete(r.dropped, taskID)
+	}
+	for len(r.pending) > 0 {
+		if err := stream.Send(r.pending[0]); err != nil {
+			return 0, err
+		}
+		r.pending = r.pending[1:]
+	}
+	r.pending = nil
+
+	r.tasksMu.Lock()
+	running := len(r.tasks)
+	r.tasksMu.Unlock()
+	if credit := r.cfg.MaxConcurrent - running; credit > 0 {
+		if err := stream.Send(&pb.PullRequest{Content: &pb.PullRequest_Credit{Credit: int32(credit)}}); err != nil {
+			return 0, err
+		}
+	}
+	r.stream = stream
+	return running, nil
+}
+
+// detach 连接断开后不再向其发送
+func (r *runner) detach(stream pb.Scheduler_PullClient) {
+	r.sendMu.Lock()
+	defer r.sendMu.Unlock()
+	if r.stream == stream {
+		r.stream = nil
+	}
+}
+
+// start 在独立协程中执行任务，超出名额或任务 ID 重复时直接回报失败
+func (r *runner) start(task *pb.PullTask) {
+	r.wg.Add(1)
+	r.tasksMu.Lock()
+	var rejectErr error
+	if _, exists := r.tasks[task.TaskId]; exists {
+		rejectErr = status.Error(codes.AlreadyExists, fmt.Sprintf("task %q is already running", task.TaskId))
+	} else if len(r.tasks) >= r.cfg.MaxConcurrent {
+		rejectErr = status.Error(codes.ResourceExhausted, "no free task slot")
+	}
+	if rejectErr != nil {
+		r.tasksMu.Unlock()
+		logit.Context(r.ctx).WarnW("logType", "pull task rejected", "taskId", task.TaskId, "reason", rejectErr.Error())
+		go func() {
+			defer r.wg.Done()
+			r.finish(task.TaskId, rejectErr, false)
+		}()
+		return
+	}
+	taskCtx, cancel := context.WithCancel(r.ctx)
+	r.tasks[task.TaskId] = cancel
+	r.tasksMu.Unlock()
+
+	go func() {
+		defer r.wg.Done()
+		req := task.Request
+		if req == nil {
+			req = &pb.TaskRequest{}
+		}
+		err := r.server.Execute(taskCtx, req, executor.NewStreamSink(&taskStream{runner: r, taskID: task.TaskId}))
+		cancel()
+		r.finish(task.TaskId, err, true)
+	}()
+}
+
+// cancel 取消正在执行的任务，任务结束后照常回报
+func (r *runner) cancel(taskID string) {
+	r.tasksMu.Lock()
+	defer r.tasksMu.Unlock()
+	if cancel, ok := r.tasks[taskID]; ok {
+		cancel()
+	}
+}
+
+// finish 回报任务结果并归还名额；断线时暂存结果，名额在重连时统一发放
+func (r *runner) finish(taskID string, err error, running bool) {
+	st := status.Convert(err)
+	done := &pb.PullDone{
+		TaskId:   taskID,
+		Code:     int32(st.Code()),
+		Message:  st.Message(),
+		ExitCode: executor.ExitCode(err),
+	}
+
+	// 删除任务与发送名额在同一次 sendMu 内完成，重连时发放的名额不会重复计算
+	r.sendMu.Lock()
+	defer r.sendMu.Unlock()
+	if running {
+		r.tasksMu.Lock()
+		delete(r.tasks, taskID)
+		r.tasksMu.Unlock()
+	}
+	if !r.sendLocked(&pb.PullRequest{Content: &pb.PullRequest_Done{Done: done}}, true) {
+		return
+	}
+	_ = r.sendLocked(&pb.PullRequest{Content: &pb.PullRequest_Credit{Credit: 1}}, false)
+}
+
+// output 发送任务输出，断线时暂存；心跳只反映当前状态，断线时直接丢弃
+func (r *runner) output(taskID string, resp *pb.TaskResponse) {
+	r.sendMu.Lock()
+	defer r.sendMu.Unlock()
+	if r.stream == nil {
+		if _, ok := resp.Content.(*pb.TaskResponse_Heartbeat); ok {
+			return
+		}
+		if len(r.pending) >= r.cfg.MaxPendingOutputs {
+			r.dropped[taskID]++
+			return
+		}
+	}
+	_ = r.sendLocked(outputRequest(taskID, resp), true)
+}
+
+// sendLocked 在当前连接上发送，返回是否已发送；断线或发送失败时按 keep 暂存，调用方需持有 sendMu
+func (r *runner) sendLocked(req *pb.PullRequest, keep bool) bool {
+	if r.stream != nil {
+		if err := r.stream.Send(req); err == nil {
+			return true
+		}
+		// 发送失败说明连接已断开，接收协程随后结束本次连接
+		r.stream = nil
+	}
+	if keep {
+		r.pending = append(r.pending, req)
+	}
+	return false
+}
+
+// outputRequest 标记任务 ID 的输出消息
+func outputRequest(taskID string, resp *pb.TaskResponse) *pb.PullRequest {
+	return &pb.PullRequest{Content: &pb.PullRequest_Output{Output: &pb.PullOutput{TaskId: taskID, Response: resp}}}
+}
+
+// taskStream 将单个任务的响应写入拉取连接，断线期间暂存，不会让任务因发送失败而中止
+type taskStream struct {
+	runner *runner
+	taskID string
+}
+
+// Send 实现 executor.ResponseSender
+func (t *taskStream) Send(resp *pb.TaskResponse) error {
+	t.runner.output(t.taskID, resp)
+	return nil
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// RegistryConfig 调度端注册配置
+type RegistryConfig struct {
+	// 是否向调度端注册
+	Enabled bool `yaml:"enabled"`
+	// 调度端地址
+	Endpoint string `yaml:"endpoint"`
+	// 是否使用 TLS
+	TLS bool `yaml:"tls"`
+	// 调度端连接本 worker 使用的地址（为空时使用 <主机名>:<grpc.yaml 端口>）
+	AdvertiseAddress string `yaml:"advertiseAddress"`
+	// 心跳间隔秒数，调度端注册响应中指定时以调度端为准
+	HeartbeatIntervalSec int `yaml:"heartbeatIntervalSec"`
+	// 单次请求超时秒数
+	RequestTimeoutSec int `yaml:"requestTimeoutSec"`
+	// 注册失败后重试的初始等待秒数，连续失败时翻倍
+	RetryBackoffSec int `yaml:"retryBackoffSec"`
+	// 重试等待秒数上限
+	MaxRetryBackoffSec int `yaml:"maxRetryBackoffSec"`
+}
+
+// Config 注册配置结构
+type Config struct {
+	Registry RegistryConfig `yaml:"registry"`
+}
+
+// grpcConfig grpc.yaml 中用到的字段
+type grpcConfig struct {
+	Port string `yaml:"port"`
+}
+
+var (
+	globalConfig Config
+	grpcPort     string
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "registry.yaml"), &globalConfig); err != nil {
+			panic("loadConfig registry.yaml err:" + err.Error())
+		}
+
+		var grpcCfg grpcConfig
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "grpc.yaml"), &grpcCfg); err != nil {
+			panic("loadConfig grpc.yaml err:" + err.Error())
+		}
+		grpcPort = grpcCfg.Port
+
+		// 设置默认值
+		if globalConfig.Registry.HeartbeatIntervalSec <= 0 {
+			globalConfig.Registry.HeartbeatIntervalSec = 10
+		}
+		if globalConfig.Registry.RequestTimeoutSec <= 0 {
+			globalConfig.Registry.RequestTimeoutSec = 5
+		}
+		if globalConfig.Registry.RetryBackoffSec <= 0 {
+			globalConfig.Registry.RetryBackoffSec = 1
+		}
+		if globalConfig.Registry.MaxRetryBackoffSec <= 0 {
+			globalConfig.Registry.MaxRetryBackoffSec = 60
+		}
+		if globalConfig.Registry.MaxRetryBackoffSec < globalConfig.Registry.RetryBackoffSec {
+			globalConfig.Registry.MaxRetryBackoffSec = globalConfig.Registry.RetryBackoffSec
+		}
+	})
+}
+
+// GetRegistryConfig 获取注册配置
+func GetRegistryConfig() RegistryConfig {
+	lazyLoadConfig()
+	return globalConfig.Registry
+}
+
+// GetGRPCPort 获取本 worker gRPC 服务监听的端口
+func GetGRPCPort() string {
+	lazyLoadConfig()
+	return grpcPort
+}
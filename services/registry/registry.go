@@ -0,0 +1,170 @@
+package registry
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"goumang-worker/services/pb"
+	"goumang-worker/services/registry/config"
+	"goumang-worker/services/worker"
+	"net"
+	"time"
+
+	"github.com/bpcoder16/Chestnut/v2/logit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// InfoProvider 提供注册与心跳上报的 worker 信息
+type InfoProvider interface {
+	GetWorkerInfo(ctx context.Context, req *pb.GetWorkerInfoRequest) (*pb.WorkerInfo, error)
+}
+
+// errReregister 调度端不再认识该 worker，需要重新注册
+var errReregister = errors.New("worker is unknown to the scheduler")
+
+// Run 向调度端注册并定时发送心跳，注册失败时按退避时间重试，ctx 结束时注销
+func Run(ctx context.Context, provider InfoProvider) error {
+	cfg := config.GetRegistryConfig()
+	if !cfg.Enabled {
+		return nil
+	}
+
+	creds := insecure.NewCredentials()
+	if cfg.TLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.NewClient(cfg.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if errC := conn.Close(); errC != nil {
+			logit.Context(ctx).WarnW("conn.Close.Err", errC)
+		}
+	}()
+
+	c := &client{
+		cfg:      cfg,
+		provider: provider,
+		client:   pb.NewSchedulerClient(conn),
+		address:  advertiseAddress(cfg),
+	}
+	c.loop(ctx)
+	return nil
+}
+
+// advertiseAddress 调度端连接本 worker 使用的地址
+func advertiseAddress(cfg config.RegistryConfig) string {
+	if cfg.AdvertiseAddress != "" {
+		return cfg.AdvertiseAddress
+	}
+	return net.JoinHostPort(worker.Hostname(), config.GetGRPCPort())
+}
+
+// client 单个调度端的注册状态
+type client struct {
+	cfg      config.RegistryConfig
+	provider InfoProvider
+	client   pb.SchedulerClient
+	address  string
+}
+
+// loop 注册后持续心跳，调度端要求时重新注册，ctx 结束时注销
+func (c *client) loop(ctx context.Context) {
+	backoff := time.Duration(c.cfg.RetryBackoffSec) * time.Second
+	maxBackoff := time.Duration(c.cfg.MaxRetryBackoffSec) * time.Second
+	wait := backoff
+
+	for ctx.Err() == nil {
+		workerID, interval, err := c.register(ctx)
+		if err != nil {
+			logit.Context(ctx).WarnW("registry.register.Err", err, "endpoint", c.cfg.Endpoint)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+			wait = min(wait*2, maxBackoff)
+			continue
+		}
+		wait = backoff
+		logit.Context(ctx).InfoW("logType", "worker registered", "endpoint", c.cfg.Endpoint, "workerId", workerID, "address", c.address)
+
+		if err = c.heartbeat(ctx, workerID, interval); err != nil {
+			logit.Context(ctx).WarnW("logType", "worker re-registering", "workerId", workerID, "reason", err.Error())
+			continue
+		}
+		c.deregister(ctx, workerID)
+	}
+}
+
+// register 上报地址与当前能力，返回调度端分配的 worker ID 与心跳间隔
+func (c *client) register(ctx context.Context) (string, time.Duration, error) {
+	info, err := c.provider.GetWorkerInfo(ctx, &pb.GetWorkerInfoRequest{})
+	if err != nil {
+		return "", 0, err
+	}
+
+	reqCtx, cancel := c.requestContext(ctx)
+	defer cancel()
+	resp, err := c.client.Register(reqCtx, &pb.RegisterRequest{Address: c.address, Info: info})
+	if err != nil {
+		return "", 0, err
+	}
+
+	interval := time.Duration(c.cfg.HeartbeatIntervalSec) * time.Second
+	if resp.HeartbeatIntervalSec > 0 {
+		interval = time.Duration(resp.HeartbeatIntervalSec) * time.Second
+	}
+	return resp.WorkerId, interval, nil
+}
+
+// heartbeat 定时上报负载，ctx 结束时返回 nil，调度端不认识该 worker 时返回 errReregister
+// 其他失败只记录日志，由调度端按心跳超时判断 worker 是否存活
+func (c *client) heartbeat(ctx context.Context, workerID string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		info, err := c.provider.GetWorkerInfo(ctx, &pb.GetWorkerInfoRequest{})
+		if err != nil {
+			logit.Context(ctx).WarnW("registry.GetWorkerInfo.Err", err)
+			continue
+		}
+		reqCtx, cancel := c.requestContext(ctx)
+		_, err = c.client.Heartbeat(reqCtx, &pb.HeartbeatRequest{WorkerId: workerID, Load: info.Load})
+		cancel()
+		if status.Code(err) == codes.NotFound {
+			return errReregister
+		}
+		if err != nil {
+			logit.Context(ctx).WarnW("registry.heartbeat.Err", err, "workerId", workerID)
+		}
+	}
+}
+
+// deregister 注销 worker，ctx 已结束，使用独立的超时
+func (c *client) deregister(ctx context.Context, workerID string) {
+	reqCtx, cancel := c.requestContext(context.WithoutCancel(ctx))
+	defer cancel()
+	if _, err := c.client.Deregister(reqCtx, &pb.DeregisterRequest{WorkerId: workerID}); err != nil {
+		logit.Context(ctx).WarnW("registry.deregister.Err", err, "workerId", workerID)
+		return
+	}
+	logit.Context(ctx).InfoW("logType", "worker deregistered", "workerId", workerID)
+}
+
+// requestContext 单次请求的超时
+func (c *client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, time.Duration(c.cfg.RequestTimeoutSec)*time.Second)
+}
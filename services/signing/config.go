@@ -0,0 +1,61 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// TrustedKey 可信公钥
+type TrustedKey struct {
+	ID        string `yaml:"id"`
+	PublicKey string `yaml:"publicKey"`
+}
+
+// SigningConfig 签名校验配置
+type SigningConfig struct {
+	// 是否要求任务签名
+	Enabled bool `yaml:"enabled"`
+	// 可信公钥列表
+	TrustedKeys []TrustedKey `yaml:"trustedKeys"`
+}
+
+// Config 签名配置结构
+type Config struct {
+	Signing SigningConfig `yaml:"signing"`
+}
+
+var (
+	globalConfig Config
+	keyring      map[string]ed25519.PublicKey
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件并解析公钥
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "signing.yaml"), &globalConfig); err != nil {
+			panic("loadConfig signing.yaml err:" + err.Error())
+		}
+
+		keyring = make(map[string]ed25519.PublicKey, len(globalConfig.Signing.TrustedKeys))
+		for _, key := range globalConfig.Signing.TrustedKeys {
+			raw, err := base64.StdEncoding.DecodeString(key.PublicKey)
+			if err != nil || len(raw) != ed25519.PublicKeySize {
+				panic(fmt.Sprintf("loadConfig signing.yaml err: invalid public key %q", key.ID))
+			}
+			keyring[key.ID] = raw
+		}
+	})
+}
+
+// GetSigningConfig 获取签名校验配置
+func GetSigningConfig() SigningConfig {
+	lazyLoadConfig()
+	return globalConfig.Signing
+}
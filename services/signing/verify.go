@@ -0,0 +1,68 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"goumang-worker/services/executor"
+	"goumang-worker/services/pb"
+)
+
+var (
+	// ErrUnsigned 请求未携带签名
+	ErrUnsigned = errors.New("task request is not signed")
+	// ErrBadSignature 签名无效或密钥不可信
+	ErrBadSignature = errors.New("task request signature is invalid")
+)
+
+// IsEnabled 是否要求任务签名
+func IsEnabled() bool {
+	return GetSigningConfig().Enabled
+}
+
+// Payload 返回签名覆盖的内容：方法名与参数，避免签名被挪用到其他方法
+func Payload(method, methodParams string) []byte {
+	return []byte(method + "\n" + methodParams)
+}
+
+// FilePayload 返回文件上传签名覆盖的内容：目录根、路径、内容哈希、权限位（八进制）与属主属组
+// 权限位按请求原值签名，未指定时为 0000
+func FilePayload(header *pb.FileHeader) []byte {
+	return fmt.Appendf(nil, "PUT_FILE\n%s\n%s\n%s\n%04o\n%s\n%s",
+		header.Root, header.Path, header.Sha256, header.Mode, header.Owner, header.Group)
+}
+
+// Verify 使用可信密钥校验请求签名，指定 key_id 时只使用该密钥
+func Verify(req *pb.TaskRequest) error {
+	return VerifyPayload(Payload(executor.RequestMethod(req), req.MethodParams), req.KeyId, req.Signature)
+}
+
+// VerifyPayload 使用可信密钥校验任意内容的签名
+func VerifyPayload(payload []byte, keyID string, signature []byte) error {
+	lazyLoadConfig()
+
+	if len(signature) == 0 {
+		return ErrUnsigned
+	}
+	if len(signature) != ed25519.SignatureSize {
+		return ErrBadSignature
+	}
+
+	if keyID != "" {
+		key, ok := keyring[keyID]
+		if !ok {
+			return fmt.Errorf("%w: unknown key %q", ErrBadSignature, keyID)
+		}
+		if !ed25519.Verify(key, payload, signature) {
+			return ErrBadSignature
+		}
+		return nil
+	}
+
+	for _, key := range keyring {
+		if ed25519.Verify(key, payload, signature) {
+			return nil
+		}
+	}
+	return ErrBadSignature
+}
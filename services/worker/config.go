@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"path"
+	"sync"
+
+	"github.com/bpcoder16/Chestnut/v2/appconfig/env"
+	"github.com/bpcoder16/Chestnut/v2/core/utils"
+)
+
+// WorkerConfig worker 自身的描述信息
+type WorkerConfig struct {
+	// 上报给调度端的主机名（为空时使用系统主机名）
+	Hostname string `yaml:"hostname"`
+	// 标签，调度端据此选择 worker
+	Labels map[string]string `yaml:"labels"`
+}
+
+// Config worker 配置结构
+type Config struct {
+	Worker WorkerConfig `yaml:"worker"`
+}
+
+var (
+	globalConfig Config
+	configOnce   sync.Once
+)
+
+// lazyLoadConfig 懒加载配置文件
+func lazyLoadConfig() {
+	configOnce.Do(func() {
+		if err := utils.ParseFile(path.Join(env.ConfigDirPath(), "worker.yaml"), &globalConfig); err != nil {
+			panic("loadConfig worker.yaml err:" + err.Error())
+		}
+	})
+}
+
+// GetWorkerConfig 获取 worker 配置
+func GetWorkerConfig() WorkerConfig {
+	lazyLoadConfig()
+	return globalConfig.Worker
+}
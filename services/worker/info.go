@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+)
+
+// Version worker 版本，发布构建时通过 -ldflags "-X goumang-worker/services/worker.Version=v1.2.3" 注入
+var Version = "dev"
+
+// Build 构建信息
+type Build struct {
+	GoVersion string
+	// VCS 提交号
+	Revision string
+	// VCS 提交时间
+	Time string
+	// 构建时工作区是否有未提交的修改
+	Modified bool
+}
+
+// BuildInfo 从二进制内嵌的构建信息中读取 Go 版本与 VCS 信息
+func BuildInfo() Build {
+	build := Build{GoVersion: runtime.Version()}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return build
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.Time = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return build
+}
+
+// Hostname 返回配置的主机名，未配置时使用系统主机名
+func Hostname() string {
+	if hostname := GetWorkerConfig().Hostname; hostname != "" {
+		return hostname
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+// Labels 返回 worker 标签的副本
+func Labels() map[string]string {
+	labels := make(map[string]string, len(GetWorkerConfig().Labels))
+	for k, v := range GetWorkerConfig().Labels {
+		labels[k] = v
+	}
+	return labels
+}